@@ -0,0 +1,80 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+// avalanche subnet addChain
+func newAddChainCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "addChain [subnetName] [chainName]",
+		Short: "Add a new blockchain configuration to an existing subnet",
+		Long: `The subnet addChain command creates the configuration of an additional
+blockchain that will be deployed into the subnet [subnetName]. The new chain has its
+own VM, genesis and chain config, and is created with the same wizard and flags
+as subnet create.
+
+Once added, the next subnet deploy of [subnetName] also deploys the new chain. If the
+subnet is already deployed to the target network, only the new chain gets created.`,
+		SilenceUsage:      true,
+		Args:              cobra.ExactArgs(2),
+		RunE:              addChain,
+		PersistentPostRun: handlePostRun,
+		Aliases:           []string{"add-chain"},
+	}
+	cmd.Flags().StringVar(&genesisFile, "genesis", "", "file path of genesis to use")
+	cmd.Flags().StringVar(&vmFile, "vm", "", "file path of custom vm to use")
+	cmd.Flags().BoolVar(&useSubnetEvm, "evm", false, "use the Subnet-EVM as the base template")
+	cmd.Flags().StringVar(&vmVersion, "vm-version", "", "version of vm template to use")
+	cmd.Flags().BoolVar(&useCustom, "custom", false, "use a custom VM template")
+	cmd.Flags().BoolVar(&useLatestVersion, latest, false, "use latest VM version, takes precedence over --vm-version")
+	return cmd
+}
+
+func addChain(cmd *cobra.Command, args []string) error {
+	subnetName := args[0]
+	chainName := args[1]
+
+	if !app.SidecarExists(subnetName) {
+		return fmt.Errorf("subnet %s does not exist", subnetName)
+	}
+	if app.SidecarExists(chainName) {
+		return fmt.Errorf("a configuration named %s already exists", chainName)
+	}
+	subnetSC, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return err
+	}
+	if subnetSC.IsSubnetChain() {
+		return fmt.Errorf("%s is a chain of subnet %s, chains can only be added to subnets", subnetName, subnetSC.Subnet)
+	}
+
+	if err := createSubnetConfig(cmd, []string{chainName}); err != nil {
+		return err
+	}
+
+	chainSC, err := app.LoadSidecar(chainName)
+	if err != nil {
+		return err
+	}
+	chainSC.Subnet = subnetName
+	if err := app.UpdateSidecar(&chainSC); err != nil {
+		return err
+	}
+	if !slices.Contains(subnetSC.Chains, chainName) {
+		subnetSC.Chains = append(subnetSC.Chains, chainName)
+	}
+	if err := app.UpdateSidecar(&subnetSC); err != nil {
+		return err
+	}
+
+	ux.Logger.PrintToUser("Chain %s added to subnet %s", chainName, subnetName)
+	ux.Logger.PrintToUser("Run 'avalanche subnet deploy %s' to deploy it", subnetName)
+	return nil
+}
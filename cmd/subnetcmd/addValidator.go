@@ -22,10 +22,12 @@ import (
 )
 
 var (
-	nodeIDStr    string
-	weight       uint64
-	startTimeStr string
-	duration     time.Duration
+	nodeIDStr      string
+	weight         uint64
+	startTimeStr   string
+	duration       time.Duration
+	validatorsFile string
+	outputTxDir    string
 
 	errNoSubnetID = errors.New("failed to find the subnet ID for this subnet, has it been deployed/created on this network?")
 )
@@ -43,6 +45,12 @@ the subnetName and the validator's unique NodeID. The command then prompts
 for the validation start time, duration, and stake weight. You can bypass
 these prompts by providing the values with flags.
 
+To add many validators at once, provide them with --from-file in a CSV file
+with a header naming the columns nodeID, weight, start-time and staking-period,
+or in a YAML file with a list of entries with the same keys. Only nodeID is
+mandatory. All the entries are validated before issuing any tx. For multisig
+subnets, one partially signed tx file per validator is saved into --output-tx-dir.
+
 This command currently only works on Subnets deployed to either the Fuji
 Testnet or Mainnet.`,
 		SilenceUsage: true,
@@ -59,6 +67,8 @@ Testnet or Mainnet.`,
 	cmd.Flags().BoolVar(&deployMainnet, "mainnet", false, "join on `mainnet`")
 	cmd.Flags().StringSliceVar(&subnetAuthKeys, "subnet-auth-keys", nil, "control keys that will be used to authenticate add validator tx")
 	cmd.Flags().StringVar(&outputTxPath, "output-tx-path", "", "file path of the add validator tx")
+	cmd.Flags().StringVar(&validatorsFile, "from-file", "", "add all the validators of the given CSV or YAML file")
+	cmd.Flags().StringVar(&outputTxDir, "output-tx-dir", "", "directory of the add validator txs, when using --from-file")
 	cmd.Flags().BoolVarP(&useLedger, "ledger", "g", false, "use ledger instead of key (always true on mainnet, defaults to false on fuji)")
 	cmd.Flags().StringSliceVar(&ledgerAddresses, "ledger-addrs", []string{}, "use the given ledger addresses")
 	return cmd
@@ -101,6 +111,10 @@ func addValidator(_ *cobra.Command, args []string) error {
 		network = models.NetworkFromString(networkStr)
 	}
 
+	if validatorsFile != "" && (nodeIDStr != "" || weight != 0 || startTimeStr != "" || duration != 0 || outputTxPath != "") {
+		return errors.New("--from-file can't be used together with --nodeID, --weight, --start-time, --staking-period or --output-tx-path")
+	}
+
 	if outputTxPath != "" {
 		if _, err := os.Stat(outputTxPath); err == nil {
 			return fmt.Errorf("outputTxPath %q already exists", outputTxPath)
@@ -170,6 +184,28 @@ func addValidator(_ *cobra.Command, args []string) error {
 	}
 	ux.Logger.PrintToUser("Your subnet auth keys for add validator tx creation: %s", subnetAuthKeys)
 
+	if validatorsFile != "" {
+		specs, err := loadValidatorsFile(validatorsFile)
+		if err != nil {
+			return err
+		}
+		primaryValidators, err := getPrimaryValidatorsEndTime(network)
+		if err != nil {
+			return err
+		}
+		validators, err := validateValidatorSpecs(specs, primaryValidators, time.Now())
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("%d validators validated, issuing transactions to add them...", len(validators))
+		kc, err := GetKeychain(useLedger, ledgerAddresses, keyName, network)
+		if err != nil {
+			return err
+		}
+		deployer := subnet.NewPublicDeployer(app, useLedger, kc, network)
+		return addValidatorsFromFile(deployer, &sc, network, subnetID, controlKeys, validators, outputTxDir)
+	}
+
 	if nodeIDStr == "" {
 		nodeID, err = promptNodeID()
 		if err != nil {
@@ -182,6 +218,18 @@ func addValidator(_ *cobra.Command, args []string) error {
 		}
 	}
 
+	// skip validators already added by a previous run
+	if txID, ok := sc.GetJournalStep(network, models.AddValidatorsJournal, nodeID.String()); ok {
+		isValidator, err := subnet.IsSubnetValidator(subnetID, nodeID, network)
+		if err != nil {
+			return err
+		}
+		if isValidator {
+			ux.Logger.PrintToUser("Node %s was already added as a validator by tx %s. Skipping", nodeID, txID)
+			return nil
+		}
+	}
+
 	if weight == 0 {
 		weight, err = promptWeight()
 		if err != nil {
@@ -213,7 +261,11 @@ func addValidator(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	if !isFullySigned {
+	if isFullySigned {
+		if err := app.UpdateSidecarJournal(&sc, network, models.AddValidatorsJournal, nodeID.String(), tx.ID()); err != nil {
+			return err
+		}
+	} else {
 		if err := SaveNotFullySignedTx(
 			"Add Validator",
 			tx,
@@ -0,0 +1,278 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	avago_constants "github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"gopkg.in/yaml.v3"
+)
+
+// column names of the validators CSV file, also used as YAML keys
+const (
+	nodeIDColumn        = "nodeID"
+	weightColumn        = "weight"
+	startTimeColumn     = "start-time"
+	stakingPeriodColumn = "staking-period"
+)
+
+// validatorSpec is a validator entry of the --from-file file
+type validatorSpec struct {
+	NodeID        string `yaml:"nodeID"`
+	Weight        uint64 `yaml:"weight"`
+	StartTime     string `yaml:"start-time"`
+	StakingPeriod string `yaml:"staking-period"`
+}
+
+// batchValidator is a validated validator entry, ready to be added
+type batchValidator struct {
+	NodeID   ids.NodeID
+	Weight   uint64
+	Start    time.Time
+	Duration time.Duration
+}
+
+// loadValidatorsFile reads the validators of a CSV file, or of a YAML file
+// if [path] has a .yml or .yaml extension
+func loadValidatorsFile(path string) ([]validatorSpec, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		specs := []validatorSpec{}
+		if err := yaml.Unmarshal(fileBytes, &specs); err != nil {
+			return nil, fmt.Errorf("invalid validators file %s: %w", path, err)
+		}
+		return specs, nil
+	default:
+		return parseValidatorsCSV(string(fileBytes))
+	}
+}
+
+// parseValidatorsCSV parses a CSV whose header names the columns. Only the
+// nodeID column is mandatory
+func parseValidatorsCSV(content string) ([]validatorSpec, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid validators CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("validators CSV is empty")
+	}
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	if _, ok := columns[nodeIDColumn]; !ok {
+		return nil, fmt.Errorf("validators CSV header must have a %q column", nodeIDColumn)
+	}
+	get := func(record []string, column string) string {
+		if i, ok := columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	specs := []validatorSpec{}
+	for line, record := range records[1:] {
+		spec := validatorSpec{
+			NodeID:        get(record, nodeIDColumn),
+			StartTime:     get(record, startTimeColumn),
+			StakingPeriod: get(record, stakingPeriodColumn),
+		}
+		if weightStr := get(record, weightColumn); weightStr != "" {
+			spec.Weight, err = strconv.ParseUint(weightStr, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid weight %q", line+2, weightStr)
+			}
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// validateValidatorSpecs checks all [specs] up front, against the end time of
+// the current [primaryValidators], and fills in the default values of the
+// optional fields. All the problems found are returned in a single error
+func validateValidatorSpecs(
+	specs []validatorSpec,
+	primaryValidators map[ids.NodeID]time.Time,
+	now time.Time,
+) ([]batchValidator, error) {
+	if len(specs) == 0 {
+		return nil, errors.New("no validators found in file")
+	}
+	validators := []batchValidator{}
+	problems := []string{}
+	seen := map[ids.NodeID]bool{}
+	for i, spec := range specs {
+		nodeID, err := ids.NodeIDFromString(spec.NodeID)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("entry %d: invalid node ID %q", i+1, spec.NodeID))
+			continue
+		}
+		if seen[nodeID] {
+			problems = append(problems, fmt.Sprintf("entry %d: duplicated node ID %s", i+1, nodeID))
+			continue
+		}
+		seen[nodeID] = true
+		primaryEnd, ok := primaryValidators[nodeID]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("entry %d: %s is not a primary network validator", i+1, nodeID))
+			continue
+		}
+		validator := batchValidator{
+			NodeID: nodeID,
+			Weight: spec.Weight,
+			Start:  now.Add(constants.StakingStartLeadTime),
+		}
+		if validator.Weight == 0 {
+			validator.Weight = constants.DefaultStakeWeight
+		}
+		if spec.StartTime != "" {
+			validator.Start, err = time.Parse(constants.TimeParseLayout, spec.StartTime)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("entry %d: invalid start time %q", i+1, spec.StartTime))
+				continue
+			}
+			if validator.Start.Before(now.Add(constants.StakingMinimumLeadTime)) {
+				problems = append(problems, fmt.Sprintf("entry %d: start time should be at least %s in the future", i+1, constants.StakingMinimumLeadTime))
+				continue
+			}
+		}
+		if spec.StakingPeriod == "" {
+			validator.Duration = primaryEnd.Sub(validator.Start)
+		} else {
+			validator.Duration, err = time.ParseDuration(spec.StakingPeriod)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("entry %d: invalid staking period %q", i+1, spec.StakingPeriod))
+				continue
+			}
+		}
+		if validator.Duration <= 0 {
+			problems = append(problems, fmt.Sprintf("entry %d: staking period must be positive", i+1))
+			continue
+		}
+		if validator.Start.Add(validator.Duration).After(primaryEnd) {
+			problems = append(problems, fmt.Sprintf(
+				"entry %d: staking period of %s ends after its primary network validation ends at %s",
+				i+1, nodeID, primaryEnd.Format(constants.TimeParseLayout),
+			))
+			continue
+		}
+		validators = append(validators, validator)
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid validators file:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return validators, nil
+}
+
+// getPrimaryValidatorsEndTime returns the end time of each current primary network validator
+func getPrimaryValidatorsEndTime(network models.Network) (map[ids.NodeID]time.Time, error) {
+	endpoint, err := network.Endpoint()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), constants.RequestTimeout)
+	defer cancel()
+	vs, err := platformvm.NewClient(endpoint).GetCurrentValidators(ctx, avago_constants.PrimaryNetworkID, nil)
+	if err != nil {
+		return nil, err
+	}
+	endTimes := map[ids.NodeID]time.Time{}
+	for _, v := range vs {
+		endTimes[v.NodeID] = time.Unix(int64(v.EndTime), 0)
+	}
+	return endTimes, nil
+}
+
+// addValidatorsFromFile adds all the validators of [validators] to the subnet.
+// Txs that are not fully signed are saved, one per validator, into [outputTxDir]
+func addValidatorsFromFile(
+	deployer *subnet.PublicDeployer,
+	sc *models.Sidecar,
+	network models.Network,
+	subnetID ids.ID,
+	controlKeys []string,
+	validators []batchValidator,
+	outputTxDir string,
+) error {
+	for _, validator := range validators {
+		id := validator.NodeID.String()
+		// skip validators already added by a previous run
+		if txID, ok := sc.GetJournalStep(network, models.AddValidatorsJournal, id); ok {
+			isValidator, err := subnet.IsSubnetValidator(subnetID, validator.NodeID, network)
+			if err != nil {
+				return err
+			}
+			if isValidator {
+				ux.Logger.PrintToUser("Node %s was already added as a validator by tx %s. Skipping", id, txID)
+				continue
+			}
+		}
+		ux.Logger.PrintToUser("")
+		ux.Logger.PrintToUser("Adding %s with weight %d from %s to %s", id, validator.Weight,
+			validator.Start.Format(constants.TimeParseLayout),
+			validator.Start.Add(validator.Duration).Format(constants.TimeParseLayout),
+		)
+		isFullySigned, tx, remainingSubnetAuthKeys, err := deployer.AddValidator(
+			controlKeys,
+			subnetAuthKeys,
+			subnetID,
+			validator.NodeID,
+			validator.Weight,
+			validator.Start,
+			validator.Duration,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", id, err)
+		}
+		if isFullySigned {
+			if err := app.UpdateSidecarJournal(sc, network, models.AddValidatorsJournal, id, tx.ID()); err != nil {
+				return err
+			}
+			continue
+		}
+		if outputTxDir == "" {
+			outputTxDir, err = app.Prompt.CaptureString("Directory to export the partially signed txs to")
+			if err != nil {
+				return err
+			}
+		}
+		if err := os.MkdirAll(outputTxDir, constants.DefaultPerms755); err != nil {
+			return err
+		}
+		if err := SaveNotFullySignedTx(
+			"Add Validator",
+			tx,
+			sc.Name,
+			subnetAuthKeys,
+			remainingSubnetAuthKeys,
+			filepath.Join(outputTxDir, id+".tx"),
+			false,
+		); err != nil {
+			return err
+		}
+	}
+	// the batch is completed, a later run starts over
+	return app.ClearSidecarJournal(sc, network, models.AddValidatorsJournal)
+}
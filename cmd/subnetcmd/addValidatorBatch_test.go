@@ -0,0 +1,85 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValidatorsCSV(t *testing.T) {
+	require := require.New(t)
+
+	specs, err := parseValidatorsCSV(`nodeID, weight, staking-period
+NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg, 30, 48h
+NodeID-MFrZFVCXPv5iCn6M9K6XduxGTYp891xXZ,,
+`)
+	require.NoError(err)
+	require.Equal([]validatorSpec{
+		{NodeID: "NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg", Weight: 30, StakingPeriod: "48h"},
+		{NodeID: "NodeID-MFrZFVCXPv5iCn6M9K6XduxGTYp891xXZ"},
+	}, specs)
+
+	_, err = parseValidatorsCSV("weight\n20\n")
+	require.ErrorContains(err, nodeIDColumn)
+
+	_, err = parseValidatorsCSV("nodeID,weight\nNodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg,heavy\n")
+	require.ErrorContains(err, "line 2")
+}
+
+func TestValidateValidatorSpecs(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	nodeID1, err := ids.NodeIDFromString("NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg")
+	require.NoError(err)
+	nodeID2, err := ids.NodeIDFromString("NodeID-MFrZFVCXPv5iCn6M9K6XduxGTYp891xXZ")
+	require.NoError(err)
+	primaryEnd := now.Add(30 * 24 * time.Hour)
+	primaryValidators := map[ids.NodeID]time.Time{
+		nodeID1: primaryEnd,
+		nodeID2: primaryEnd,
+	}
+
+	validators, err := validateValidatorSpecs([]validatorSpec{
+		{NodeID: nodeID1.String(), Weight: 30, StakingPeriod: "48h"},
+		{NodeID: nodeID2.String(), StartTime: "2023-01-02 00:00:00"},
+	}, primaryValidators, now)
+	require.NoError(err)
+	require.Equal([]batchValidator{
+		{
+			NodeID:   nodeID1,
+			Weight:   30,
+			Start:    now.Add(constants.StakingStartLeadTime),
+			Duration: 48 * time.Hour,
+		},
+		{
+			NodeID:   nodeID2,
+			Weight:   constants.DefaultStakeWeight,
+			Start:    now.Add(24 * time.Hour),
+			Duration: 29 * 24 * time.Hour,
+		},
+	}, validators)
+
+	// all problems are reported at once
+	_, err = validateValidatorSpecs([]validatorSpec{
+		{NodeID: "NodeID-invalid"},
+		{NodeID: nodeID1.String(), StakingPeriod: "1000h"},
+		{NodeID: nodeID2.String()},
+		{NodeID: nodeID2.String()},
+		{NodeID: ids.GenerateTestNodeID().String()},
+	}, primaryValidators, now)
+	require.ErrorContains(err, "entry 1: invalid node ID")
+	require.ErrorContains(err, "entry 2: staking period")
+	require.ErrorContains(err, "entry 4: duplicated node ID")
+	require.ErrorContains(err, "entry 5: ")
+	require.ErrorContains(err, "is not a primary network validator")
+	require.NotContains(err.Error(), "entry 3")
+
+	_, err = validateValidatorSpecs(nil, primaryValidators, now)
+	require.Error(err)
+}
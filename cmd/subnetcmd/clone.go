@@ -0,0 +1,144 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var cloneChainID string
+
+// avalanche subnet clone
+func newCloneCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clone [sourceSubnetName] [newSubnetName]",
+		Short: "Create a new subnet configuration as a copy of an existing one",
+		Long: `The subnet clone command creates a new subnet configuration that copies
+the genesis, chain and subnet configs and upgrade files of an existing one.
+
+The clone starts undeployed, with its own VMID. For Subnet-EVM subnets, the clone
+gets a fresh ChainID, taken from --chain-id or prompted. Custom VM binaries are
+copied along.`,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(2),
+		RunE:         cloneSubnet,
+	}
+	cmd.Flags().StringVar(&cloneChainID, "chain-id", "", "ChainID to use for the cloned Subnet-EVM genesis")
+	return cmd
+}
+
+func cloneSubnet(_ *cobra.Command, args []string) error {
+	srcName := args[0]
+	dstName := args[1]
+
+	if !app.SidecarExists(srcName) {
+		return fmt.Errorf("subnet %s does not exist", srcName)
+	}
+	if app.SidecarExists(dstName) || app.GenesisExists(dstName) {
+		return fmt.Errorf("a configuration named %s already exists", dstName)
+	}
+	if err := checkInvalidSubnetNames(dstName); err != nil {
+		return fmt.Errorf("subnet name %q is invalid: %w", dstName, err)
+	}
+
+	sc, err := app.LoadSidecar(srcName)
+	if err != nil {
+		return err
+	}
+
+	// deploy specific files are not cloned: the new subnet starts undeployed
+	skipFiles := []string{
+		constants.SidecarFileName,
+		constants.GenesisMainnetFileName,
+		constants.ElasticSubnetConfigFileName,
+		constants.UpgradeBytesFileName + constants.UpgradeBytesLockExtension,
+	}
+	if err := app.CopySubnetDir(srcName, dstName, skipFiles); err != nil {
+		return err
+	}
+
+	if sc.VM == models.CustomVM {
+		if _, err := os.Stat(app.GetCustomVMPath(srcName)); err == nil {
+			if err := app.CopyVMBinary(app.GetCustomVMPath(srcName), dstName); err != nil {
+				return err
+			}
+		}
+	}
+
+	newSC := sc
+	newSC.Name = dstName
+	newSC.Subnet = dstName
+	newSC.Networks = nil
+	newSC.ElasticSubnet = nil
+	newSC.Chains = nil
+	// a pending deploy of the source must not be resumed into its subnet by the clone
+	newSC.Journals = nil
+
+	if sc.VM == models.SubnetEvm {
+		chainID, err := setClonedChainID(dstName)
+		if err != nil {
+			return err
+		}
+		newSC.ChainID = chainID.String()
+	} else if cloneChainID != "" {
+		ux.Logger.PrintToUser("The --chain-id flag is ignored for custom VMs, the genesis is copied as is")
+	}
+
+	if err := app.CreateSidecar(&newSC); err != nil {
+		return err
+	}
+
+	if len(sc.Chains) > 0 {
+		ux.Logger.PrintToUser("Note: the additional chains of %s were not cloned", srcName)
+	}
+	ux.Logger.PrintToUser("Successfully cloned subnet configuration %s into %s", srcName, dstName)
+	return nil
+}
+
+// setClonedChainID sets a fresh ChainID into the Subnet-EVM genesis of [subnetName]
+func setClonedChainID(subnetName string) (*big.Int, error) {
+	evmGenesis, err := app.LoadEvmGenesis(subnetName)
+	if err != nil {
+		return nil, err
+	}
+	var chainID *big.Int
+	if cloneChainID != "" {
+		newChainID, ok := new(big.Int).SetString(cloneChainID, 10)
+		if !ok || newChainID.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid chain id %q: must be a positive integer", cloneChainID)
+		}
+		chainID = newChainID
+	} else {
+		ux.Logger.PrintToUser("Enter the ChainID of the cloned subnet. It can be any positive integer.")
+		chainID, err = app.Prompt.CapturePositiveBigInt("ChainID")
+		if err != nil {
+			return nil, err
+		}
+	}
+	if evmGenesis.Config == nil {
+		return nil, errors.New("genesis has no chain config")
+	}
+	if evmGenesis.Config.ChainID != nil && evmGenesis.Config.ChainID.Cmp(chainID) == 0 {
+		ux.Logger.PrintToUser("Warning: the clone uses the same ChainID as the source subnet. This could lead to replay attacks")
+	}
+	evmGenesis.Config.ChainID = chainID
+	jsonBytes, err := evmGenesis.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var prettyJSON bytes.Buffer
+	if err := json.Indent(&prettyJSON, jsonBytes, "", "    "); err != nil {
+		return nil, err
+	}
+	return chainID, app.WriteGenesisFile(subnetName, prettyJSON.Bytes())
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/internal/mocks"
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/ava-labs/avalanche-cli/tests/e2e/utils"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCloneTestSubnet(t *testing.T, subnetName string) *require.Assertions {
+	require := require.New(t)
+	testSubnetEVMCompat := []byte("{\"rpcChainVMProtocolVersion\": {\"v0.9.99\": 18}}")
+
+	app = application.New()
+	mockAppDownloader := mocks.Downloader{}
+	mockAppDownloader.On("Download", mock.Anything).Return(testSubnetEVMCompat, nil)
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompts.NewPrompter(), &mockAppDownloader)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+
+	genBytes, sc, err := vm.CreateEvmSubnetConfig(app, subnetName, "../../"+utils.SubnetEvmGenesisPath, "", "v0.9.99")
+	require.NoError(err)
+	require.NoError(app.WriteGenesisFile(subnetName, genBytes))
+	require.NoError(app.CreateSidecar(sc))
+	chainConfigPath := filepath.Join(app.GetSubnetDir(), subnetName, constants.ChainConfigFileName)
+	require.NoError(os.WriteFile(chainConfigPath, []byte("{}"), constants.WriteReadReadPerms))
+	require.NoError(app.WriteUpgradeFile(subnetName, []byte("{}")))
+	require.NoError(app.WriteLockUpgradeFile(subnetName, []byte("{}")))
+	return require
+}
+
+func TestCloneSubnet(t *testing.T) {
+	require := setupCloneTestSubnet(t, "srcSubnet")
+	defer func() {
+		cloneChainID = ""
+		app = nil
+	}()
+
+	// a deploy of the source stopped after creating its subnet
+	srcSC, err := app.LoadSidecar("srcSubnet")
+	require.NoError(err)
+	require.NoError(app.UpdateSidecarJournal(&srcSC, models.Fuji, models.DeployJournal, createSubnetStep, ids.GenerateTestID()))
+
+	cloneChainID = "12345"
+	require.NoError(cloneSubnet(nil, []string{"srcSubnet", "dstSubnet"}))
+
+	sc, err := app.LoadSidecar("dstSubnet")
+	require.NoError(err)
+	require.Equal("dstSubnet", sc.Name)
+	require.Equal("dstSubnet", sc.Subnet)
+	require.Equal("12345", sc.ChainID)
+	require.Empty(sc.Networks)
+	require.Empty(sc.Journals)
+	_, ok := sc.GetJournalStep(models.Fuji, models.DeployJournal, createSubnetStep)
+	require.False(ok)
+
+	genesis, err := app.LoadEvmGenesis("dstSubnet")
+	require.NoError(err)
+	require.Equal("12345", genesis.Config.ChainID.String())
+
+	require.FileExists(filepath.Join(app.GetSubnetDir(), "dstSubnet", constants.ChainConfigFileName))
+	require.FileExists(app.GetUpgradeBytesFilePath("dstSubnet"))
+	require.NoFileExists(app.GetUpgradeBytesFilePath("dstSubnet") + constants.UpgradeBytesLockExtension)
+
+	// source is left untouched
+	srcGenesis, err := app.LoadEvmGenesis("srcSubnet")
+	require.NoError(err)
+	require.NotEqual("12345", srcGenesis.Config.ChainID.String())
+
+	// destination already exists
+	require.Error(cloneSubnet(nil, []string{"srcSubnet", "dstSubnet"}))
+	// invalid chain id
+	cloneChainID = "-1"
+	require.Error(cloneSubnet(nil, []string{"srcSubnet", "otherSubnet"}))
+}
+
+func TestRenameSubnet(t *testing.T) {
+	require := setupCloneTestSubnet(t, "oldSubnet")
+	defer func() {
+		forceRename = false
+		app = nil
+	}()
+
+	require.NoError(renameSubnet(nil, []string{"oldSubnet", "newSubnet"}))
+	require.False(app.SidecarExists("oldSubnet"))
+	sc, err := app.LoadSidecar("newSubnet")
+	require.NoError(err)
+	require.Equal("newSubnet", sc.Name)
+	require.Equal("newSubnet", sc.Subnet)
+	require.FileExists(app.GetUpgradeBytesFilePath("newSubnet") + constants.UpgradeBytesLockExtension)
+
+	// deployed subnets can only be renamed with --force
+	require.NoError(app.UpdateSidecarNetworks(&sc, models.Fuji, ids.GenerateTestID(), ids.GenerateTestID()))
+	require.Error(renameSubnet(nil, []string{"newSubnet", "otherSubnet"}))
+	require.True(app.SidecarExists("newSubnet"))
+	forceRename = true
+	require.NoError(renameSubnet(nil, []string{"newSubnet", "otherSubnet"}))
+	require.True(app.SidecarExists("otherSubnet"))
+}
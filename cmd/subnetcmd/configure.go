@@ -26,7 +26,10 @@ func newConfigureCmd() *cobra.Command {
 		Short: "Adds additional config files for the avalanchego nodes",
 		Long: `AvalancheGo nodes support several different configuration files. Subnets have their own
 Subnet config which applies to all chains/VMs in the Subnet. Each chain within the Subnet
-can have its own chain config. This command allows you to set both config files.`,
+can have its own chain config. This command allows you to set both config files.
+
+The files are checked against the config schemas, and unknown keys produce a warning.
+To edit single values, use subnet configure chain.`,
 		SilenceUsage: true,
 		RunE:         configure,
 		Args:         cobra.ExactArgs(1),
@@ -35,6 +38,12 @@ can have its own chain config. This command allows you to set both config files.
 	cmd.Flags().StringVar(&subnetConf, "subnet-config", "", "path to the subnet configuration")
 	cmd.Flags().StringVar(&chainConf, "chain-config", "", "path to the chain configuration")
 	cmd.Flags().StringVar(&perNodeChainConf, "per-node-chain-config", "", "path to per node chain configuration for local network")
+	// subnet configure genesis
+	cmd.AddCommand(newConfigureGenesisCmd())
+	// subnet configure describer
+	cmd.AddCommand(newConfigureDescriberCmd())
+	// subnet configure chain
+	cmd.AddCommand(newConfigureChainCmd())
 	return cmd
 }
 
@@ -106,6 +115,11 @@ func updateConf(subnet, path, filename string) error {
 	if err != nil {
 		return err
 	}
+	warnings, err := validateConfigBytes(subnet, filename, fileBytes)
+	printConfigWarnings(filename, warnings)
+	if err != nil {
+		return err
+	}
 	subnetDir := filepath.Join(app.GetSubnetDir(), subnet)
 	if err := os.MkdirAll(subnetDir, constants.DefaultPerms755); err != nil {
 		return err
@@ -0,0 +1,314 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ava-labs/avalanche-cli/cmd/flags"
+	"github.com/ava-labs/avalanche-cli/pkg/chainconfig"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+const (
+	setChainConfig      = "set"
+	getChainConfig      = "get"
+	validateChainConfig = "validate"
+)
+
+var (
+	useSubnetConfig bool
+	configNode      string
+)
+
+// avalanche subnet configure chain
+func newConfigureChainCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chain [subnetName] [set|get|validate] [key] [value]",
+		Short: "Sets, gets or validates values of the chain and subnet configs",
+		Long: `The subnet configure chain command edits the chain config of a subnet one value at a
+time, checking keys, types and ranges against the config schema of Subnet-EVM.
+
+set writes a value, get prints it, and validate checks every config file of the
+subnet. Keys of nested objects are joined with dots, as in consensusParameters.k,
+and list values are given comma separated. Durations accept values such as 1m30s.
+
+Unknown keys are allowed but produce a warning, as the node silently ignores them.
+
+With --subnet-config, the avalanchego subnet config is edited instead. With --node,
+the chain config override of a node of the local network is edited. An override
+replaces the whole chain config for that node, so it starts as a copy of it.`,
+		SilenceUsage: true,
+		RunE:         configureChain,
+		Args:         cobra.RangeArgs(2, 4),
+	}
+	cmd.Flags().BoolVar(&useSubnetConfig, "subnet-config", false, "edit the subnet config instead of the chain config")
+	cmd.Flags().StringVar(&configNode, "node", "", "edit the chain config override of this local network node (e.g. node1)")
+	return cmd
+}
+
+func configureChain(_ *cobra.Command, args []string) error {
+	chains, err := ValidateSubnetNameAndGetChains(args[:1])
+	if err != nil {
+		return err
+	}
+	subnetName := chains[0]
+	action := args[1]
+	switch action {
+	case setChainConfig:
+		if len(args) != 4 {
+			return errors.New("set expects a key and a value")
+		}
+	case getChainConfig:
+		if len(args) > 3 {
+			return errors.New("get expects at most a key")
+		}
+	case validateChainConfig:
+		if len(args) != 2 {
+			return errors.New("validate does not expect a key")
+		}
+		return validateSubnetConfigFiles(subnetName)
+	default:
+		return fmt.Errorf("invalid action %q, expected one of %s, %s, %s",
+			action, setChainConfig, getChainConfig, validateChainConfig)
+	}
+	if !flags.EnsureMutuallyExclusive([]bool{useSubnetConfig, configNode != ""}) {
+		return errors.New("--subnet-config and --node are mutually exclusive")
+	}
+
+	filename := constants.ChainConfigFileName
+	switch {
+	case useSubnetConfig:
+		filename = constants.SubnetConfigFileName
+	case configNode != "":
+		filename = constants.PerNodeChainConfigFileName
+	}
+	schema, err := getConfigSchema(subnetName, filename)
+	if err != nil {
+		return err
+	}
+	fileConfig, err := loadConfigFile(subnetName, filename)
+	if err != nil {
+		return err
+	}
+	config := fileConfig
+	if configNode != "" {
+		config, err = getNodeConfig(subnetName, fileConfig, configNode)
+		if err != nil {
+			return err
+		}
+	}
+
+	if action == getChainConfig {
+		if len(args) == 2 {
+			configBytes, err := chainconfig.Encode(config)
+			if err != nil {
+				return err
+			}
+			ux.Logger.PrintToUser(string(configBytes))
+			return nil
+		}
+		return printConfigValue(config, args[2])
+	}
+
+	key, valueStr := args[2], args[3]
+	var value interface{}
+	if schema == nil {
+		value = chainconfig.ParseRawValue(valueStr)
+	} else {
+		value, err = schema.ParseValue(key, valueStr)
+		if errors.Is(err, chainconfig.ErrUnknownKey) {
+			value = chainconfig.ParseRawValue(valueStr)
+		} else if err != nil {
+			return err
+		}
+	}
+	if err := chainconfig.SetValue(config, key, value); err != nil {
+		return err
+	}
+	if schema != nil {
+		warnings, err := schema.Validate(config)
+		printConfigWarnings(filename, warnings)
+		if err != nil {
+			return err
+		}
+	}
+	if configNode != "" {
+		fileConfig[configNode] = config
+	}
+	if err := writeConfigFile(subnetName, filename, fileConfig); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("%s set in %s", key, filename)
+	return nil
+}
+
+// getConfigSchema returns the schema of the config [filename] of [subnetName], or
+// nil if its VM has no known chain config schema
+func getConfigSchema(subnetName, filename string) (*chainconfig.Schema, error) {
+	if filename == constants.SubnetConfigFileName {
+		return &chainconfig.SubnetSchema, nil
+	}
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return nil, err
+	}
+	if sc.VM != models.SubnetEvm {
+		return nil, nil
+	}
+	return &chainconfig.EvmSchema, nil
+}
+
+func getConfigPath(subnetName, filename string) string {
+	return filepath.Join(app.GetSubnetDir(), subnetName, filename)
+}
+
+// loadConfigFile reads the config [filename] of [subnetName], which is empty if
+// it does not exist yet
+func loadConfigFile(subnetName, filename string) (map[string]interface{}, error) {
+	configBytes, err := os.ReadFile(getConfigPath(subnetName, filename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]interface{}{}, nil
+		}
+		return nil, err
+	}
+	config, err := chainconfig.Decode(configBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return config, nil
+}
+
+func writeConfigFile(subnetName, filename string, config map[string]interface{}) error {
+	configBytes, err := chainconfig.Encode(config)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(app.GetSubnetDir(), subnetName), constants.DefaultPerms755); err != nil {
+		return err
+	}
+	return os.WriteFile(getConfigPath(subnetName, filename), configBytes, constants.WriteReadReadPerms)
+}
+
+// getNodeConfig returns the chain config override of [node] from the per node
+// config [perNodeConfig]. A new override starts as a copy of the chain config,
+// as the override replaces it for the node
+func getNodeConfig(subnetName string, perNodeConfig map[string]interface{}, node string) (map[string]interface{}, error) {
+	nodeConfig, ok := perNodeConfig[node]
+	if !ok {
+		return loadConfigFile(subnetName, constants.ChainConfigFileName)
+	}
+	nodeConfigMap, ok := nodeConfig.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the chain config of node %s in %s is not an object", node, constants.PerNodeChainConfigFileName)
+	}
+	return nodeConfigMap, nil
+}
+
+func printConfigValue(config map[string]interface{}, key string) error {
+	value, ok := chainconfig.GetValue(config, key)
+	if !ok {
+		ux.Logger.PrintToUser("%s is not set, the node uses its default value", key)
+		return nil
+	}
+	if str, ok := value.(string); ok {
+		ux.Logger.PrintToUser(str)
+		return nil
+	}
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser(string(valueBytes))
+	return nil
+}
+
+func printConfigWarnings(filename string, warnings []string) {
+	for _, warning := range warnings {
+		ux.Logger.PrintToUser("Warning: %s: %s", filename, warning)
+	}
+}
+
+// validateConfigBytes checks the config [filename] of [subnetName] against its
+// schema, returning the warnings about unknown keys
+func validateConfigBytes(subnetName, filename string, configBytes []byte) ([]string, error) {
+	schema, err := getConfigSchema(subnetName, filename)
+	if err != nil {
+		return nil, err
+	}
+	config, err := chainconfig.Decode(configBytes)
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		return nil, nil
+	}
+	if filename != constants.PerNodeChainConfigFileName {
+		return schema.Validate(config)
+	}
+	nodes := make([]string, 0, len(config))
+	for node := range config {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	warnings := []string{}
+	for _, node := range nodes {
+		nodeConfig, ok := config[node].(map[string]interface{})
+		if !ok {
+			return warnings, fmt.Errorf("the chain config of node %s is not an object", node)
+		}
+		nodeWarnings, err := schema.Validate(nodeConfig)
+		for _, warning := range nodeWarnings {
+			warnings = append(warnings, fmt.Sprintf("node %s: %s", node, warning))
+		}
+		if err != nil {
+			return warnings, fmt.Errorf("node %s: %w", node, err)
+		}
+	}
+	return warnings, nil
+}
+
+// validateSubnetConfigFiles checks each existing config file of [subnetName]
+func validateSubnetConfigFiles(subnetName string) error {
+	invalid := false
+	found := false
+	for _, filename := range []string{
+		constants.ChainConfigFileName,
+		constants.SubnetConfigFileName,
+		constants.PerNodeChainConfigFileName,
+	} {
+		configBytes, err := os.ReadFile(getConfigPath(subnetName, filename))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		found = true
+		warnings, err := validateConfigBytes(subnetName, filename, configBytes)
+		printConfigWarnings(filename, warnings)
+		if err != nil {
+			ux.Logger.PrintToUser("%s: %s", filename, err)
+			invalid = true
+			continue
+		}
+		ux.Logger.PrintToUser("%s is valid", filename)
+	}
+	if !found {
+		ux.Logger.PrintToUser("No config files set for %s", subnetName)
+		return nil
+	}
+	if invalid {
+		return fmt.Errorf("invalid config files for %s", subnetName)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/spf13/cobra"
+)
+
+var (
+	genesisSchemaPath string
+	describeHookPath  string
+)
+
+// avalanche subnet configure describer
+func newConfigureDescriberCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "describer [subnetName]",
+		Short: "Sets how subnet describe prints the genesis of a custom VM",
+		Long: `The subnet configure describer command tells subnet describe and subnet list how
+to read the genesis of a custom VM subnet.
+
+A genesis schema is a JSON file with the path of the chain ID and the list of fields
+to print, using dot separated keys and array indexes:
+
+  {"chainID": "config.chainId", "fields": [{"name": "Owner", "path": "owners.0"}]}
+
+A describe hook is an executable that gets the subnet name as argument and the genesis
+on its standard input, and prints its own summary. If both are set, the hook is used.
+As it is executed by subnet describe, the hook is never included in subnet bundles and
+has to be set on each machine.
+Without any of them, the genesis is printed as a generic JSON tree.`,
+		SilenceUsage: true,
+		RunE:         configureDescriber,
+		Args:         cobra.ExactArgs(1),
+	}
+	cmd.Flags().StringVar(&genesisSchemaPath, "schema", "", "path to the genesis schema")
+	cmd.Flags().StringVar(&describeHookPath, "hook", "", "path to the describe hook binary")
+	return cmd
+}
+
+func configureDescriber(_ *cobra.Command, args []string) error {
+	subnetName := args[0]
+	if !app.SidecarExists(subnetName) {
+		return fmt.Errorf("subnet %s does not exist", subnetName)
+	}
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return err
+	}
+	if sc.VM != models.CustomVM {
+		return errors.New("describers can only be configured for custom VM subnets")
+	}
+	if genesisSchemaPath == "" && describeHookPath == "" {
+		return errors.New("provide a genesis schema with --schema or a describe hook with --hook")
+	}
+	if genesisSchemaPath != "" {
+		schemaBytes, err := os.ReadFile(genesisSchemaPath)
+		if err != nil {
+			return err
+		}
+		var schema vm.GenesisSchema
+		if err := json.Unmarshal(schemaBytes, &schema); err != nil {
+			return fmt.Errorf("invalid genesis schema: %w", err)
+		}
+		if err := os.WriteFile(app.GetGenesisSchemaPath(subnetName), schemaBytes, constants.WriteReadReadPerms); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Genesis schema set for %s", subnetName)
+	}
+	if describeHookPath != "" {
+		hookBytes, err := os.ReadFile(describeHookPath)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(app.GetDescribeHookPath(subnetName), hookBytes, constants.DefaultPerms755); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Describe hook set for %s", subnetName)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	genesisStage       string
+	genesisAirdropFile string
+)
+
+// avalanche subnet configure genesis
+func newConfigureGenesisCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "genesis [subnetName]",
+		Short: "Edits the genesis of a not yet deployed Subnet-EVM subnet",
+		Long: `The subnet configure genesis command re-enters a single stage of the Subnet-EVM
+creation wizard on the existing genesis of the subnet, without having to
+create it again. The available stages are chain-id, token, fee, airdrop and precompiles.
+
+The edited genesis is verified before being saved. As the genesis can't be changed
+once the blockchain exists, the command refuses to edit subnets deployed to Fuji or Mainnet.
+
+With --airdrop-file, the airdrop stage replaces the allocations with the ones of a CSV
+or JSON file, in the format described by subnet create.`,
+		SilenceUsage: true,
+		RunE:         configureGenesis,
+		Args:         cobra.ExactArgs(1),
+	}
+	cmd.Flags().StringVar(&genesisStage, "stage", "", fmt.Sprintf("genesis stage to edit (one of %v)", vm.GetEditableEvmGenesisStages()))
+	cmd.Flags().StringVar(&genesisAirdropFile, "airdrop-file", "", "file path of a CSV or JSON file with the new allocations (implies the airdrop stage)")
+	return cmd
+}
+
+func configureGenesis(_ *cobra.Command, args []string) error {
+	subnetName := args[0]
+	if !app.SidecarExists(subnetName) || !app.GenesisExists(subnetName) {
+		return fmt.Errorf("subnet %s does not exist", subnetName)
+	}
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return err
+	}
+	if sc.VM != models.SubnetEvm {
+		return errors.New("only Subnet-EVM genesis can be edited. Provide a new genesis with subnet create --genesis instead")
+	}
+	for _, network := range []models.Network{models.Fuji, models.Mainnet} {
+		if sc.Networks[network.String()].BlockchainID != ids.Empty {
+			return fmt.Errorf("subnet %s is already deployed to %s, its genesis can't be changed anymore", subnetName, network.String())
+		}
+	}
+
+	if genesisAirdropFile != "" {
+		if genesisStage != "" && genesisStage != vm.AirdropStage {
+			return fmt.Errorf("--airdrop-file can only be used with the %s stage", vm.AirdropStage)
+		}
+		genesisStage = vm.AirdropStage
+	}
+	if genesisStage == "" {
+		genesisStage, err = app.Prompt.CaptureList(
+			"Which part of the genesis would you like to edit?",
+			vm.GetEditableEvmGenesisStages(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if !slices.Contains(vm.GetEditableEvmGenesisStages(), genesisStage) {
+		return fmt.Errorf("invalid genesis stage %q, must be one of %v", genesisStage, vm.GetEditableEvmGenesisStages())
+	}
+
+	genesisBytes, err := vm.EditEvmGenesis(app, &sc, genesisStage, genesisAirdropFile)
+	if err != nil {
+		if errors.Is(err, vm.ErrGenesisEditCancelled) {
+			ux.Logger.PrintToUser("No changes applied")
+			return nil
+		}
+		return err
+	}
+	if err := app.WriteGenesisFile(subnetName, genesisBytes); err != nil {
+		return err
+	}
+	if err := app.UpdateSidecar(&sc); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Genesis of %s successfully updated", subnetName)
+
+	if sc.Networks[models.Local.String()].BlockchainID != ids.Empty {
+		ux.Logger.PrintToUser("The subnet is deployed to the local network with the previous genesis. Run 'avalanche network clean' and deploy it again to use the new one")
+	}
+	if _, err := os.Stat(app.GetGenesisMainnetPath(subnetName)); err == nil {
+		ux.Logger.PrintToUser("Note: the Mainnet genesis at %s was not updated", app.GetGenesisMainnetPath(subnetName))
+	}
+	return nil
+}
@@ -8,7 +8,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"unicode"
 
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
@@ -29,13 +28,11 @@ var (
 	forceCreate      bool
 	useSubnetEvm     bool
 	genesisFile      string
+	airdropFile      string
 	vmFile           string
 	useCustom        bool
 	vmVersion        string
 	useLatestVersion bool
-
-	errIllegalNameCharacter = errors.New(
-		"illegal name character: only letters, no special characters allowed")
 )
 
 // avalanche subnet create
@@ -53,7 +50,11 @@ the path to your genesis and VM binaries with the --genesis and --vm flags.
 
 By default, running the command with a subnetName that already exists
 causes the command to fail. If you’d like to overwrite an existing
-configuration, pass the -f flag.`,
+configuration, pass the -f flag.
+
+For Subnet-EVM, --airdrop-file takes the genesis allocations from a CSV file with
+address,amount rows or from a JSON list of {"address", "amount"} objects, instead of
+the airdrop prompts. Amounts are in whole tokens unless followed by wei, gwei or ether.`,
 		SilenceUsage:      true,
 		Args:              cobra.ExactArgs(1),
 		RunE:              createSubnetConfig,
@@ -61,6 +62,7 @@ configuration, pass the -f flag.`,
 	}
 	cmd.Flags().StringVar(&genesisFile, "genesis", "", "file path of genesis to use")
 	cmd.Flags().StringVar(&vmFile, "vm", "", "file path of custom vm to use")
+	cmd.Flags().StringVar(&airdropFile, "airdrop-file", "", "file path of a CSV or JSON file with the Subnet-EVM genesis allocations")
 	cmd.Flags().BoolVar(&useSubnetEvm, "evm", false, "use the Subnet-EVM as the base template")
 	cmd.Flags().StringVar(&vmVersion, "vm-version", "", "version of vm template to use")
 	cmd.Flags().BoolVar(&useCustom, "custom", false, "use a custom VM template")
@@ -136,9 +138,13 @@ func createSubnetConfig(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid version string, should be semantic version (ex: v1.1.1): %s", vmVersion)
 	}
 
+	if airdropFile != "" && subnetType != models.SubnetEvm {
+		return errors.New("--airdrop-file is only supported for Subnet-EVM")
+	}
+
 	switch subnetType {
 	case models.SubnetEvm:
-		genesisBytes, sc, err = vm.CreateEvmSubnetConfig(app, subnetName, genesisFile, vmVersion)
+		genesisBytes, sc, err = vm.CreateEvmSubnetConfig(app, subnetName, genesisFile, airdropFile, vmVersion)
 		if err != nil {
 			return err
 		}
@@ -199,12 +205,5 @@ func sendMetrics(cmd *cobra.Command, repoName, subnetName string) error {
 }
 
 func checkInvalidSubnetNames(name string) error {
-	// this is currently exactly the same code as in avalanchego/vms/platformvm/create_chain_tx.go
-	for _, r := range name {
-		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsNumber(r) || r == ' ') {
-			return errIllegalNameCharacter
-		}
-	}
-
-	return nil
+	return utils.ValidateSubnetName(name)
 }
@@ -4,57 +4,148 @@ package subnetcmd
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// prefix used by the network runner for the snapshot directories
+const snapshotDirPrefix = "anr-snapshot-"
+
+var (
+	keepBinaries bool
+	forceDelete  bool
 )
 
 // avalanche subnet delete
 func newDeleteCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "delete",
 		Short: "Delete a subnet configuration",
-		Long:  "The subnet delete command deletes an existing subnet configuration.",
-		RunE:  deleteSubnet,
-		Args:  cobra.ExactArgs(1),
+		Long: `The subnet delete command deletes an existing subnet configuration.
+
+Together with the configuration, the command removes the custom VM binary of the
+subnet, its temporary upgrade snapshots, and the imported VM binary and installed
+plugin of its VM, as long as no other subnet configuration still uses them. The
+plugin of a subnet deployed on the local network is kept, as the saved local network
+still loads it, until avalanche network clean. Pass --keep-binaries to leave all VM binaries and plugins in place.
+
+The chain configurations of a subnet are deleted with it, after a confirmation
+prompt unless --force is given.`,
+		RunE: deleteSubnet,
+		Args: cobra.ExactArgs(1),
 	}
+	cmd.Flags().BoolVar(&keepBinaries, "keep-binaries", false, "do not remove VM binaries nor plugins")
+	cmd.Flags().BoolVarP(&forceDelete, forceFlag, "f", false, "delete the chains of the subnet without prompting")
+	return cmd
 }
 
 func deleteSubnet(_ *cobra.Command, args []string) error {
 	// TODO sanitize this input
 	subnetName := args[0]
-	subnetDir := filepath.Join(app.GetSubnetDir(), subnetName)
 
-	customVMPath := app.GetCustomVMPath(subnetName)
+	sidecar, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return err
+	}
+
+	chains := []string{}
+	if !sidecar.IsSubnetChain() {
+		for _, chain := range sidecar.Chains {
+			if app.SidecarExists(chain) {
+				chains = append(chains, chain)
+			}
+		}
+	}
+	if len(chains) > 0 && !forceDelete {
+		yes, err := app.Prompt.CaptureYesNo(fmt.Sprintf("Subnet %s has the chains %s. Delete them too?", subnetName, chains))
+		if err != nil {
+			return err
+		}
+		if !yes {
+			return fmt.Errorf("refusing to delete %s while its chains %s exist. Delete them first or use --%s", subnetName, chains, forceFlag)
+		}
+	}
+	for _, chain := range chains {
+		if err := deleteSubnetConfig(chain); err != nil {
+			return fmt.Errorf("failed deleting chain %s: %w", chain, err)
+		}
+		ux.Logger.PrintToUser("Deleted chain %s", chain)
+	}
+	return deleteSubnetConfig(subnetName)
+}
+
+// deleteSubnetConfig deletes the configuration of [subnetName], together with
+// the binaries and snapshots nothing else uses
+func deleteSubnetConfig(subnetName string) error {
+	subnetDir := filepath.Join(app.GetSubnetDir(), subnetName)
 
 	sidecar, err := app.LoadSidecar(subnetName)
 	if err != nil {
 		return err
 	}
 
-	if sidecar.VM == models.CustomVM {
-		if _, err := os.Stat(customVMPath); err != nil {
-			if !errors.Is(err, fs.ErrNotExist) {
+	if isDeployedLocally(sidecar) {
+		ux.Logger.PrintToUser(logging.Red.Wrap(fmt.Sprintf(
+			"WARNING: %s is deployed on the local network. Its VM plugin is kept so the network and its snapshot keep working. Run 'avalanche network clean' to reset the local network",
+			subnetName,
+		)))
+	}
+
+	otherSidecars, err := loadOtherSidecars(subnetName)
+	if err != nil {
+		return err
+	}
+
+	if !keepBinaries {
+		orphans, err := getOrphanedBinaries(sidecar, otherSidecars)
+		if err != nil {
+			return err
+		}
+		for _, orphan := range orphans {
+			if err := removeIfExists(orphan); err != nil {
 				return err
 			}
-			app.Log.Warn("tried to remove custom VM path but it actually does not exist. Ignoring")
-			return nil
 		}
+	}
 
-		// exists
-		if err := os.Remove(customVMPath); err != nil {
+	snapshots, err := getSubnetSnapshots(subnetName)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		if err := os.RemoveAll(snapshot); err != nil {
 			return err
 		}
 	}
 
-	// TODO this method does not delete the imported VM binary if this
-	// is an APM subnet. We can't naively delete the binary because it
-	// may be used by multiple subnets. We should delete this binary,
-	// but only if no other subnet is using it.
-	// More info: https://github.com/ava-labs/avalanche-cli/issues/246
+	// keep the parent subnet consistent
+	if sidecar.IsSubnetChain() {
+		for _, other := range otherSidecars {
+			if other.Name != sidecar.Subnet {
+				continue
+			}
+			chains := []string{}
+			for _, chain := range other.Chains {
+				if chain != subnetName {
+					chains = append(chains, chain)
+				}
+			}
+			other.Chains = chains
+			if err := app.UpdateSidecar(&other); err != nil {
+				return err
+			}
+		}
+	}
 
 	if _, err := os.Stat(subnetDir); err != nil {
 		if !errors.Is(err, fs.ErrNotExist) {
@@ -70,3 +161,98 @@ func deleteSubnet(_ *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// loadOtherSidecars loads the sidecars of all subnet configurations but [subnetName]
+func loadOtherSidecars(subnetName string) ([]models.Sidecar, error) {
+	names, err := app.GetSidecarNames()
+	if err != nil {
+		return nil, err
+	}
+	sidecars := []models.Sidecar{}
+	for _, name := range names {
+		if name == subnetName {
+			continue
+		}
+		sc, err := app.LoadSidecar(name)
+		if err != nil {
+			return nil, err
+		}
+		sidecars = append(sidecars, sc)
+	}
+	return sidecars, nil
+}
+
+// getOrphanedBinaries returns the paths of the VM binaries and plugins of [sc]
+// that are not used by any of the [otherSidecars].
+// The installed plugin of a subnet deployed on the local network is never considered
+// orphaned, as the local network, running or saved, still loads it
+func getOrphanedBinaries(sc models.Sidecar, otherSidecars []models.Sidecar) ([]string, error) {
+	vmID, err := sc.GetVMID()
+	if err != nil {
+		return nil, err
+	}
+	vmIDInUse := false
+	for _, other := range otherSidecars {
+		otherVMID, err := other.GetVMID()
+		if err != nil {
+			return nil, err
+		}
+		if otherVMID == vmID {
+			vmIDInUse = true
+			break
+		}
+	}
+
+	orphans := []string{}
+	// custom VM binaries are stored by subnet name, so they belong to this subnet only
+	if sc.VM == models.CustomVM && !sc.ImportedFromAPM {
+		orphans = append(orphans, app.GetCustomVMPath(sc.Name))
+	}
+	if vmIDInUse {
+		return orphans, nil
+	}
+	if sc.ImportedFromAPM {
+		orphans = append(orphans, app.GetAPMVMPath(vmID))
+	}
+	if !isDeployedLocally(sc) {
+		orphans = append(orphans, filepath.Join(app.GetPluginsDir(), vmID))
+	}
+	return orphans, nil
+}
+
+// getSubnetSnapshots returns the paths of the temporary snapshots
+// that upgrade apply saved for [subnetName]
+func getSubnetSnapshots(subnetName string) ([]string, error) {
+	entries, err := os.ReadDir(app.GetSnapshotsDir())
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	prefix := snapshotDirPrefix + subnetName + "-tmp-"
+	snapshots := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), prefix) {
+			snapshots = append(snapshots, filepath.Join(app.GetSnapshotsDir(), entry.Name()))
+		}
+	}
+	return snapshots, nil
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	app.Log.Debug("removed orphaned file", zap.String("path", path))
+	return nil
+}
+
+// isDeployedLocally returns true if [sc] records a deploy to the local network,
+// which 'avalanche network clean' removes
+func isDeployedLocally(sc models.Sidecar) bool {
+	return sc.Networks[models.Local.String()].SubnetID != ids.Empty
+}
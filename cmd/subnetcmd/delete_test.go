@@ -0,0 +1,81 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrphanedBinaries(t *testing.T) {
+	require := require.New(t)
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, nil, nil)
+	defer func() {
+		app = nil
+	}()
+
+	imported := models.Sidecar{Name: "imported", VM: models.CustomVM, ImportedFromAPM: true, ImportedVMID: "sharedvmid"}
+	otherImported := models.Sidecar{Name: "otherImported", VM: models.CustomVM, ImportedFromAPM: true, ImportedVMID: "sharedvmid"}
+	custom := models.Sidecar{Name: "custom", VM: models.CustomVM}
+	customVMID, err := custom.GetVMID()
+	require.NoError(err)
+
+	// VM binary and plugin still used by another subnet
+	orphans, err := getOrphanedBinaries(imported, []models.Sidecar{otherImported, custom})
+	require.NoError(err)
+	require.Empty(orphans)
+
+	// last subnet using the VM
+	orphans, err = getOrphanedBinaries(imported, []models.Sidecar{custom})
+	require.NoError(err)
+	require.ElementsMatch([]string{
+		app.GetAPMVMPath("sharedvmid"),
+		filepath.Join(app.GetPluginsDir(), "sharedvmid"),
+	}, orphans)
+
+	// custom binaries belong to the subnet only, plugin kept if deployed on the local network
+	deployed := custom
+	deployed.Networks = map[string]models.NetworkData{
+		models.Local.String(): {SubnetID: ids.GenerateTestID()},
+	}
+	orphans, err = getOrphanedBinaries(deployed, []models.Sidecar{imported})
+	require.NoError(err)
+	require.Equal([]string{app.GetCustomVMPath("custom")}, orphans)
+
+	orphans, err = getOrphanedBinaries(custom, []models.Sidecar{imported})
+	require.NoError(err)
+	require.ElementsMatch([]string{
+		app.GetCustomVMPath("custom"),
+		filepath.Join(app.GetPluginsDir(), customVMID),
+	}, orphans)
+}
+
+func TestDeleteSubnetWithChains(t *testing.T) {
+	require := require.New(t)
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, nil, nil)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	defer func() {
+		app = nil
+		forceDelete = false
+	}()
+
+	parent := &models.Sidecar{Name: "parent", Subnet: "parent", VM: models.SubnetEvm, Chains: []string{"chain"}}
+	chain := &models.Sidecar{Name: "chain", Subnet: "parent", VM: models.SubnetEvm}
+	require.NoError(app.CreateSidecar(parent))
+	require.NoError(app.CreateSidecar(chain))
+
+	forceDelete = true
+	require.NoError(deleteSubnet(nil, []string{"parent"}))
+	require.False(app.SidecarExists("parent"))
+	require.False(app.SidecarExists("chain"))
+}
@@ -31,6 +31,7 @@ import (
 	ledger "github.com/ava-labs/avalanchego/utils/crypto/ledger"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/coreth/core"
 	"github.com/olekukonko/tablewriter"
@@ -39,7 +40,11 @@ import (
 	"golang.org/x/mod/semver"
 )
 
-const numLedgerAddressesToSearch = 1000
+const (
+	numLedgerAddressesToSearch = 1000
+	// deploy journal step of the subnet creation
+	createSubnetStep = "CreateSubnetTx"
+)
 
 var (
 	deployLocal              bool
@@ -57,6 +62,8 @@ var (
 	subnetIDStr              string
 	mainnetChainID           string
 	skipCreatePrompt         bool
+	deployDryRun             bool
+	skipLint                 bool
 
 	errMutuallyExlusiveNetworks    = errors.New("--local, --fuji (resp. --testnet) and --mainnet are mutually exclusive")
 	errMutuallyExlusiveControlKeys = errors.New("--control-keys and --same-control-key are mutually exclusive")
@@ -99,42 +106,11 @@ so you can take your locally tested Subnet and deploy it on Fuji or Mainnet.`,
 	cmd.Flags().StringSliceVar(&ledgerAddresses, "ledger-addrs", []string{}, "use the given ledger addresses")
 	cmd.Flags().StringVarP(&subnetIDStr, "subnet-id", "u", "", "deploy into given subnet id [fuji/mainnet deploy only]")
 	cmd.Flags().StringVar(&mainnetChainID, "mainnet-chain-id", "", "use different ChainID for mainnet deployment")
+	cmd.Flags().BoolVar(&deployDryRun, "dry-run", false, "check the deploy txs, fees and balance without issuing anything [fuji/mainnet deploy only]")
+	cmd.Flags().BoolVar(&skipLint, "skip-lint", false, "deploy even if the genesis has lint errors [mainnet deploy only]")
 	return cmd
 }
 
-func getChainsInSubnet(subnetName string) ([]string, error) {
-	subnets, err := os.ReadDir(app.GetSubnetDir())
-	if err != nil {
-		return nil, fmt.Errorf("failed to read baseDir: %w", err)
-	}
-
-	chains := []string{}
-
-	for _, s := range subnets {
-		if !s.IsDir() {
-			continue
-		}
-		sidecarFile := filepath.Join(app.GetSubnetDir(), s.Name(), constants.SidecarFileName)
-		if _, err := os.Stat(sidecarFile); err == nil {
-			// read in sidecar file
-			jsonBytes, err := os.ReadFile(sidecarFile)
-			if err != nil {
-				return nil, fmt.Errorf("failed reading file %s: %w", sidecarFile, err)
-			}
-
-			var sc models.Sidecar
-			err = json.Unmarshal(jsonBytes, &sc)
-			if err != nil {
-				return nil, fmt.Errorf("failed unmarshaling file %s: %w", sidecarFile, err)
-			}
-			if sc.Subnet == subnetName {
-				chains = append(chains, sc.Name)
-			}
-		}
-	}
-	return chains, nil
-}
-
 func checkDefaultAddressNotInAlloc(network models.Network, chain string) error {
 	if network != models.Local && os.Getenv(constants.SimulatePublicNetwork) == "" {
 		genesis, err := app.LoadEvmGenesis(chain)
@@ -151,6 +127,24 @@ func checkDefaultAddressNotInAlloc(network models.Network, chain string) error {
 	return nil
 }
 
+// checkMainnetGenesisLint prints the lint issues of the Mainnet genesis of [chain],
+// and fails on errors unless --skip-lint is set
+func checkMainnetGenesisLint(chain string) error {
+	issues, err := lintSubnet(chain, models.Mainnet)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+	ux.Logger.PrintToUser("Lint issues of the Mainnet genesis of %s:", chain)
+	printLintIssues(issues)
+	if vm.HasLintErrors(issues) && !skipLint {
+		return fmt.Errorf("the Mainnet genesis of %s has lint errors. Fix them, or deploy anyway with --skip-lint", chain)
+	}
+	return nil
+}
+
 func runDeploy(cmd *cobra.Command, args []string) error {
 	skipCreatePrompt = true
 	return deploySubnet(cmd, args)
@@ -189,6 +183,34 @@ func createMainnetGenesis(chain string) error {
 	return app.WriteGenesisMainnetFile(chain, prettyJSON.Bytes())
 }
 
+// checkChainsBeforeDeploy sets the Mainnet chain ID and checks the genesis of each
+// of the [chains] to deploy to [network]. A dry run keeps the chain IDs as they are
+func checkChainsBeforeDeploy(network models.Network, chains []string) error {
+	for _, chain := range chains {
+		if !deployDryRun && (network == models.Mainnet || os.Getenv(constants.SimulatePublicNetwork) != "") {
+			if err := handleMainnetChainID(chain); err != nil {
+				return err
+			}
+		}
+		if err := checkDefaultAddressNotInAlloc(network, chain); err != nil {
+			return err
+		}
+		if network != models.Mainnet || os.Getenv(constants.SimulatePublicNetwork) != "" {
+			continue
+		}
+		sc, err := app.LoadSidecar(chain)
+		if err != nil {
+			return err
+		}
+		if sc.VM == models.SubnetEvm {
+			if err := checkMainnetGenesisLint(chain); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func handleMainnetChainID(chain string) error {
 	genesisMainnetPath := app.GetGenesisMainnetPath(chain)
 	_, err := os.ReadFile(genesisMainnetPath)
@@ -286,21 +308,18 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 		network = models.NetworkFromString(networkStr)
 	}
 
-	if network == models.Mainnet || os.Getenv(constants.SimulatePublicNetwork) != "" {
-		err = handleMainnetChainID(chain)
-		if err != nil {
-			return err
-		}
+	if deployDryRun && network == models.Local {
+		return errors.New("--dry-run is only supported on Fuji and Mainnet")
 	}
 
-	// deploy based on chosen network
-	ux.Logger.PrintToUser("Deploying %s to %s", chains, network.String())
-	chainGenesis, err := app.LoadRawGenesis(chain, network)
-	if err != nil {
+	// all the chains of the subnet are checked before anything is issued
+	if err := checkChainsBeforeDeploy(network, chains); err != nil {
 		return err
 	}
 
-	err = checkDefaultAddressNotInAlloc(network, chain)
+	// deploy based on chosen network
+	ux.Logger.PrintToUser("Deploying %s to %s", chains, network.String())
+	chainGenesis, err := app.LoadRawGenesis(chain, network)
 	if err != nil {
 		return err
 	}
@@ -333,31 +352,10 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 	case models.Local:
 		app.Log.Debug("Deploy local")
 
-		// copy vm binary to the expected location, first downloading it if necessary
-		var vmBin string
-		switch sidecar.VM {
-		case models.SubnetEvm:
-			vmBin, err = binutils.SetupSubnetEVM(app, sidecar.VMVersion)
-			if err != nil {
-				return fmt.Errorf("failed to install subnet-evm: %w", err)
-			}
-		case models.CustomVM:
-			vmBin = binutils.SetupCustomBin(app, chain)
-		default:
-			return fmt.Errorf("unknown vm: %s", sidecar.VM)
-		}
-
-		// skip rpc check if using custom vm
-		if sidecar.VM != models.CustomVM {
-			// check if selected version matches what is currently running
-			nc := localnetworkinterface.NewStatusChecker()
-			userProvidedAvagoVersion, err = CheckForInvalidDeployAndGetAvagoVersion(nc, sidecar.RPCVersion)
-			if err != nil {
-				return err
-			}
+		deployer, err := getLocalDeployer(sidecar, chain)
+		if err != nil {
+			return err
 		}
-
-		deployer := subnet.NewLocalDeployer(app, userProvidedAvagoVersion, vmBin)
 		subnetID, blockchainID, err := deployer.DeployToLocalNetwork(chain, chainGenesis, genesisPath)
 		if err != nil {
 			if deployer.BackendStartedHere() {
@@ -370,7 +368,15 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 		flags := make(map[string]string)
 		flags[constants.Network] = network.String()
 		utilspkg.HandleTracking(cmd, app, flags)
-		return app.UpdateSidecarNetworks(&sidecar, network, subnetID, blockchainID)
+		if subnetID == ids.Empty {
+			// the chain was already deployed, keep the existing deploy info
+			subnetID = sidecar.Networks[network.String()].SubnetID
+			blockchainID = sidecar.Networks[network.String()].BlockchainID
+		}
+		if err := app.UpdateSidecarNetworks(&sidecar, network, subnetID, blockchainID); err != nil {
+			return err
+		}
+		return deployChainsToLocalSubnet(chains[1:], subnetID)
 
 	case models.Fuji:
 		if !useLedger && keyName == "" {
@@ -404,14 +410,26 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 	}
 
 	createSubnet := true
+	deployFirstChain := true
 	var subnetID ids.ID
 
+	pendingChains, err := getChainsPendingDeploy(chains[1:], network)
+	if err != nil {
+		return err
+	}
+
 	if subnetIDStr != "" {
 		subnetID, err = ids.FromString(subnetIDStr)
 		if err != nil {
 			return err
 		}
 		createSubnet = false
+	} else if journalSubnetID, ok := sidecar.GetJournalStep(network, models.DeployJournal, createSubnetStep); ok &&
+		sidecar.Networks[network.String()].SubnetID == ids.Empty {
+		// a previous deploy created the subnet but didn't finish
+		ux.Logger.PrintToUser("Resuming previous deploy: subnet %s was already created", journalSubnetID)
+		subnetID = journalSubnetID
+		createSubnet = false
 	} else if sidecar.Networks != nil {
 		model, ok := sidecar.Networks[network.String()]
 		if ok {
@@ -419,6 +437,12 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 				subnetID = model.SubnetID
 				createSubnet = false
 			}
+			// subnet already deployed, only its newly added chains are pending
+			if model.SubnetID != ids.Empty && model.BlockchainID != ids.Empty && len(pendingChains) > 0 {
+				subnetID = model.SubnetID
+				createSubnet = false
+				deployFirstChain = false
+			}
 		}
 	}
 
@@ -486,11 +510,23 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 	// deploy to public network
 	deployer := subnet.NewPublicDeployer(app, useLedger, kc, network)
 
+	chainsToDeploy := pendingChains
+	if deployFirstChain {
+		chainsToDeploy = append([]string{chain}, pendingChains...)
+	}
+
+	if deployDryRun {
+		return runDeployDryRun(deployer, network, subnetID, chainsToDeploy)
+	}
+
 	if createSubnet {
 		subnetID, err = deployer.DeploySubnet(controlKeys, threshold)
 		if err != nil {
 			return err
 		}
+		if err := app.UpdateSidecarJournal(&sidecar, network, models.DeployJournal, createSubnetStep, subnetID); err != nil {
+			return err
+		}
 		// get the control keys in the same order as the tx
 		controlKeys, threshold, err = txutils.GetOwners(network, subnetID)
 		if err != nil {
@@ -498,6 +534,49 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	flags := make(map[string]string)
+	flags[constants.Network] = network.String()
+	utilspkg.HandleTracking(cmd, app, flags)
+
+	txPath := outputTxPath
+	for _, c := range chainsToDeploy {
+		deployed, err := deployPublicChain(deployer, c, network, subnetID, txPath)
+		if err != nil {
+			return err
+		}
+		if !deployed {
+			return nil
+		}
+		// the given output path can only be used once
+		txPath = ""
+	}
+	// all txs confirmed, nothing left to resume
+	sidecar, err = app.LoadSidecar(chain)
+	if err != nil {
+		return err
+	}
+	return app.ClearSidecarJournal(&sidecar, network, models.DeployJournal)
+}
+
+// deployPublicChain issues the CreateChainTx for [chain] into the public [subnetID],
+// saving the tx to disk if more signatures are needed, and updates the chain's sidecar.
+// returns false if the blockchain could not be created
+func deployPublicChain(
+	deployer *subnet.PublicDeployer,
+	chain string,
+	network models.Network,
+	subnetID ids.ID,
+	txPath string,
+) (bool, error) {
+	sc, err := app.LoadSidecar(chain)
+	if err != nil {
+		return false, err
+	}
+	chainGenesis, err := app.LoadRawGenesis(chain, network)
+	if err != nil {
+		return false, err
+	}
+
 	isFullySigned, blockchainID, tx, remainingSubnetAuthKeys, err := deployer.DeployBlockchain(controlKeys, subnetAuthKeys, subnetID, chain, chainGenesis)
 	if err != nil {
 		ux.Logger.PrintToUser(logging.Red.Wrap(
@@ -508,7 +587,7 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 	savePartialTx := !isFullySigned && err == nil
 
 	if err := PrintDeployResults(chain, subnetID, blockchainID); err != nil {
-		return err
+		return false, err
 	}
 
 	if savePartialTx {
@@ -518,20 +597,143 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 			chain,
 			subnetAuthKeys,
 			remainingSubnetAuthKeys,
-			outputTxPath,
+			txPath,
 			false,
 		); err != nil {
-			return err
+			return false, err
 		}
 	}
 
-	flags := make(map[string]string)
-	flags[constants.Network] = network.String()
-	utilspkg.HandleTracking(cmd, app, flags)
-
 	// update sidecar
 	// TODO: need to do something for backwards compatibility?
-	return app.UpdateSidecarNetworks(&sidecar, network, subnetID, blockchainID)
+	if err := app.UpdateSidecarNetworks(&sc, network, subnetID, blockchainID); err != nil {
+		return false, err
+	}
+	return err == nil, nil
+}
+
+// runDeployDryRun checks the deploy of [chains] into [subnetID] (to be created if empty)
+// without issuing any tx, printing the fees to be paid against the payer's balance
+func runDeployDryRun(deployer *subnet.PublicDeployer, network models.Network, subnetID ids.ID, chains []string) error {
+	geneses := [][]byte{}
+	for _, chain := range chains {
+		chainGenesis, err := app.LoadRawGenesis(chain, network)
+		if err != nil {
+			return err
+		}
+		geneses = append(geneses, chainGenesis)
+	}
+	result, err := deployer.DeployDryRun(controlKeys, threshold, subnetAuthKeys, subnetID, chains, geneses)
+	if err != nil {
+		return err
+	}
+
+	ux.Logger.PrintToUser("Dry run of the deploy to %s. No tx has been issued", network.String())
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Item", "Value"})
+	table.SetRowLine(true)
+	table.SetAutoMergeCells(true)
+	table.Append([]string{"Payer Addresses", strings.Join(result.PayerAddresses, "\n")})
+	if subnetID == ids.Empty {
+		table.Append([]string{"Create Subnet Fee", formatAvax(result.CreateSubnetFee)})
+	} else {
+		table.Append([]string{"Subnet ID", subnetID.String()})
+	}
+	table.Append([]string{"Create Blockchain Fee", fmt.Sprintf("%s x %d", formatAvax(result.CreateChainFee), result.NumChains)})
+	table.Append([]string{"Total Fee", formatAvax(result.TotalFee())})
+	table.Append([]string{"P-Chain Balance", formatAvax(result.Balance)})
+	table.Render()
+
+	if len(result.Errors) > 0 {
+		for _, e := range result.Errors {
+			ux.Logger.PrintToUser(logging.Red.Wrap(e))
+		}
+		return errors.New("dry run failed: the deploy would not succeed")
+	}
+	ux.Logger.PrintToUser(logging.Green.Wrap("Dry run successful: the deploy is ready to be issued"))
+	return nil
+}
+
+func formatAvax(amount uint64) string {
+	return fmt.Sprintf("%.9f AVAX", float64(amount)/float64(units.Avax))
+}
+
+// getChainsPendingDeploy returns the subnet chains that have no blockchain on [network] yet
+func getChainsPendingDeploy(chains []string, network models.Network) ([]string, error) {
+	pending := []string{}
+	for _, chain := range chains {
+		sc, err := app.LoadSidecar(chain)
+		if err != nil {
+			return nil, err
+		}
+		if sc.Networks[network.String()].BlockchainID == ids.Empty {
+			pending = append(pending, chain)
+		}
+	}
+	return pending, nil
+}
+
+// getLocalDeployer sets up the VM binary for [chain] and checks the local network
+// can run it, returning a deployer ready for it
+func getLocalDeployer(sc models.Sidecar, chain string) (*subnet.LocalDeployer, error) {
+	var err error
+	// copy vm binary to the expected location, first downloading it if necessary
+	var vmBin string
+	switch sc.VM {
+	case models.SubnetEvm:
+		vmBin, err = binutils.SetupSubnetEVM(app, sc.VMVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to install subnet-evm: %w", err)
+		}
+	case models.CustomVM:
+		vmBin = binutils.SetupCustomBin(app, chain)
+	default:
+		return nil, fmt.Errorf("unknown vm: %s", sc.VM)
+	}
+
+	// skip rpc check if using custom vm
+	if sc.VM != models.CustomVM {
+		// check if selected version matches what is currently running
+		nc := localnetworkinterface.NewStatusChecker()
+		userProvidedAvagoVersion, err = CheckForInvalidDeployAndGetAvagoVersion(nc, sc.RPCVersion)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return subnet.NewLocalDeployer(app, userProvidedAvagoVersion, vmBin), nil
+}
+
+// deployChainsToLocalSubnet deploys the additional [chains] of a subnet
+// into the already deployed local [subnetID]
+func deployChainsToLocalSubnet(chains []string, subnetID ids.ID) error {
+	for _, chain := range chains {
+		sc, err := app.LoadSidecar(chain)
+		if err != nil {
+			return err
+		}
+		chainGenesis, err := app.LoadRawGenesis(chain, models.Local)
+		if err != nil {
+			return err
+		}
+		deployer, err := getLocalDeployer(sc, chain)
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Deploying chain %s into subnet %s", chain, sc.Subnet)
+		_, blockchainID, err := deployer.DeployChainToLocalSubnet(chain, chainGenesis, app.GetGenesisPath(chain), subnetID)
+		if err != nil {
+			return err
+		}
+		if blockchainID == ids.Empty {
+			// already deployed
+			continue
+		}
+		if err := app.UpdateSidecarNetworks(&sc, models.Local, subnetID, blockchainID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func getControlKeys(network models.Network, useLedger bool, kc keychain.Keychain) ([]string, bool, error) {
@@ -716,7 +918,7 @@ func ValidateSubnetNameAndGetChains(args []string) ([]string, error) {
 	}
 	// Check subnet exists
 	// TODO create a file that lists chains by subnet for fast querying
-	chains, err := getChainsInSubnet(args[0])
+	chains, err := app.GetChainsInSubnet(args[0])
 	if err != nil {
 		return nil, fmt.Errorf("failed to getChainsInSubnet: %w", err)
 	}
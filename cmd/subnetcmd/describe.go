@@ -3,6 +3,8 @@
 package subnetcmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -12,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
 	"github.com/ava-labs/avalanche-network-runner/utils"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/subnet-evm/core"
@@ -36,7 +39,15 @@ func newDescribeCmd() *cobra.Command {
 		Short: "Print a summary of the subnet’s configuration",
 		Long: `The subnet describe command prints the details of a Subnet configuration to the console.
 By default, the command prints a summary of the configuration. By providing the --genesis
-flag, the command instead prints out the raw genesis file.`,
+flag, the command instead prints out the raw genesis file.
+
+Custom VM genesis is described with the schema or hook set with subnet configure
+describer, or otherwise printed as a generic JSON tree.
+
+With --local, --fuji or --mainnet, the command also queries the given network for the
+live state of the deployed subnet: its owners, blockchains, validators and elastic status
+from the P-Chain, and the block height, chain config and fee config from the chain RPC.
+Any difference with the local genesis and upgrade files is highlighted.`,
 		RunE: readGenesis,
 		Args: cobra.ExactArgs(1),
 	}
@@ -47,6 +58,10 @@ flag, the command instead prints out the raw genesis file.`,
 		false,
 		"Print the genesis to the console directly instead of the summary",
 	)
+	cmd.Flags().BoolVar(&deployLocal, "local", false, "also describe the live `local` deployment")
+	cmd.Flags().BoolVar(&deployTestnet, "fuji", false, "also describe the live `fuji` deployment (alias for `testnet`)")
+	cmd.Flags().BoolVar(&deployTestnet, "testnet", false, "also describe the live `testnet` deployment (alias for `fuji`)")
+	cmd.Flags().BoolVar(&deployMainnet, "mainnet", false, "also describe the live `mainnet` deployment")
 	return cmd
 }
 
@@ -60,7 +75,7 @@ func printGenesis(subnetName string) error {
 	return nil
 }
 
-func printDetails(genesis core.Genesis, sc models.Sidecar) {
+func printDetails(chainID string, sc models.Sidecar) {
 	const art = `
  _____       _        _ _
 |  __ \     | |      (_) |
@@ -77,7 +92,10 @@ func printDetails(genesis core.Genesis, sc models.Sidecar) {
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
 
 	table.Append([]string{"Subnet Name", sc.Subnet})
-	table.Append([]string{"ChainID", genesis.Config.ChainID.String()})
+	if chainID == "" {
+		chainID = constants.NotAvailableLabel
+	}
+	table.Append([]string{"ChainID", chainID})
 	table.Append([]string{"Token Name", app.GetTokenName(sc.Subnet)})
 	table.Append([]string{"VM Version", sc.VMVersion})
 	if sc.ImportedVMID != "" {
@@ -250,22 +268,52 @@ func appendToAddressTable(
 	}
 }
 
-func describeSubnetEvmGenesis(sc models.Sidecar) error {
-	// Load genesis
-	genesis, err := app.LoadEvmGenesis(sc.Subnet)
+// evmDescriber describes Subnet-EVM genesis
+type evmDescriber struct{}
+
+func (evmDescriber) GetChainID(genesisBytes []byte) (string, error) {
+	genesis, err := loadEvmGenesis(genesisBytes)
 	if err != nil {
-		return err
+		return "", err
 	}
+	return genesis.Config.ChainID.String(), nil
+}
 
-	printDetails(genesis, sc)
+func (evmDescriber) Describe(_ models.Sidecar, genesisBytes []byte) error {
+	genesis, err := loadEvmGenesis(genesisBytes)
+	if err != nil {
+		return err
+	}
 	// Write gas table
 	printGasTable(genesis)
-	// fmt.Printf("\n\n")
 	printAirdropTable(genesis)
 	printPrecompileTable(genesis)
 	return nil
 }
 
+func loadEvmGenesis(genesisBytes []byte) (core.Genesis, error) {
+	var genesis core.Genesis
+	if err := json.Unmarshal(genesisBytes, &genesis); err != nil {
+		return core.Genesis{}, err
+	}
+	if genesis.Config == nil || genesis.Config.ChainID == nil {
+		return core.Genesis{}, errors.New("genesis has no Subnet-EVM chain config")
+	}
+	return genesis, nil
+}
+
+// getDescriber returns the describer for the genesis of [sc]
+func getDescriber(sc models.Sidecar) (vm.Describer, error) {
+	switch sc.VM {
+	case models.SubnetEvm:
+		return evmDescriber{}, nil
+	case models.CustomVM:
+		return vm.GetCustomDescriber(app, sc)
+	default:
+		return vm.GenericDescriber{}, nil
+	}
+}
+
 func readGenesis(_ *cobra.Command, args []string) error {
 	subnetName := args[0]
 	if !app.GenesisExists(subnetName) {
@@ -280,14 +328,56 @@ func readGenesis(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-
-	switch sc.VM {
-	case models.SubnetEvm:
-		return describeSubnetEvmGenesis(sc)
+	if err := describeChain(sc); err != nil {
+		return err
+	}
+	// describe also the additional blockchains of the subnet
+	for _, chain := range sc.Chains {
+		chainSC, err := app.LoadSidecar(chain)
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("")
+		ux.Logger.PrintToUser("Chain %s of subnet %s", chain, subnetName)
+		if err := describeChain(chainSC); err != nil {
+			return err
+		}
+	}
+	var network models.Network
+	switch {
+	case deployLocal:
+		network = models.Local
+	case deployTestnet:
+		network = models.Fuji
+	case deployMainnet:
+		network = models.Mainnet
 	default:
-		app.Log.Warn("Unknown genesis format", zap.Any("vm-type", sc.VM))
+		return nil
+	}
+	return describeLive(sc, network)
+}
+
+func describeChain(sc models.Sidecar) error {
+	genesis, err := os.ReadFile(app.GetGenesisPath(sc.Name))
+	if err != nil {
+		return err
+	}
+	describer, err := getDescriber(sc)
+	if err != nil {
+		return err
+	}
+	chainID, err := describer.GetChainID(genesis)
+	if err != nil {
+		app.Log.Warn("failed to get chain ID from genesis", zap.String("subnet", sc.Name), zap.Error(err))
+	}
+	if chainID == "" {
+		chainID = sc.ChainID
+	}
+	printDetails(chainID, sc)
+	if err := describer.Describe(sc, genesis); err != nil {
+		app.Log.Warn("failed to describe genesis", zap.Any("vm-type", sc.VM), zap.Error(err))
 		ux.Logger.PrintToUser("Printing genesis")
-		err = printGenesis(subnetName)
+		return printGenesis(sc.Name)
 	}
-	return err
+	return nil
 }
@@ -0,0 +1,284 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/txutils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/olekukonko/tablewriter"
+)
+
+const feeConfigAPI = "eth_feeConfig"
+
+// liveFeeConfig mirrors the response of the eth_feeConfig API
+type liveFeeConfig struct {
+	FeeConfig commontype.FeeConfig `json:"feeConfig"`
+}
+
+// liveEvmState holds the data read from the RPC of a running subnet-evm chain
+type liveEvmState struct {
+	BlockHeight uint64
+	ChainConfig params.ChainConfigWithUpgradesJSON
+	FeeConfig   commontype.FeeConfig
+}
+
+// describeLive prints what the P-Chain and the chain RPC report for the
+// deployment of [sc] on [network], highlighting any drift from the local files
+func describeLive(sc models.Sidecar, network models.Network) error {
+	subnetID := sc.Networks[network.String()].SubnetID
+	if subnetID == ids.Empty {
+		ux.Logger.PrintToUser("%s has not been deployed to %s", sc.Name, network.String())
+		return nil
+	}
+	endpoint, err := network.Endpoint()
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Live %s data for %s", network.String(), sc.Name)
+	if err := printPChainDetails(sc, network, endpoint, subnetID); err != nil {
+		return err
+	}
+	chains := append([]string{sc.Name}, sc.Chains...)
+	for _, chain := range chains {
+		chainSC := sc
+		if chain != sc.Name {
+			chainSC, err = app.LoadSidecar(chain)
+			if err != nil {
+				return err
+			}
+		}
+		if err := printChainDetails(chainSC, network, endpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printPChainDetails(sc models.Sidecar, network models.Network, endpoint string, subnetID ids.ID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.E2ERequestTimeout)
+	defer cancel()
+	pClient := platformvm.NewClient(endpoint)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"P-Chain", "Value"})
+	table.SetRowLine(true)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	table.Append([]string{"SubnetID", subnetID.String()})
+	owners, threshold, err := txutils.GetOwners(network, subnetID)
+	if err != nil {
+		return err
+	}
+	table.Append([]string{"Owners", strings.Join(owners, "\n")})
+	table.Append([]string{"Threshold", strconv.FormatUint(uint64(threshold), 10)})
+
+	blockchains, err := pClient.GetBlockchains(ctx)
+	if err != nil {
+		return err
+	}
+	for _, blockchain := range blockchains {
+		if blockchain.SubnetID == subnetID {
+			table.Append([]string{"Blockchain " + blockchain.Name, blockchain.ID.String()})
+		}
+	}
+
+	current, err := pClient.GetCurrentValidators(ctx, subnetID, nil)
+	if err != nil {
+		return err
+	}
+	totalWeight := uint64(0)
+	for _, validator := range current {
+		totalWeight += validator.Weight
+	}
+	pending, _, err := pClient.GetPendingValidators(ctx, subnetID, nil)
+	if err != nil {
+		return err
+	}
+	table.Append([]string{"Current Validators", strconv.Itoa(len(current))})
+	table.Append([]string{"Pending Validators", strconv.Itoa(len(pending))})
+	table.Append([]string{"Total Weight", strconv.FormatUint(totalWeight, 10)})
+
+	elasticStatus := "Not elastic"
+	assetID, err := pClient.GetStakingAssetID(ctx, subnetID)
+	switch {
+	case err == nil:
+		elasticStatus = fmt.Sprintf("Elastic (asset %s)", assetID)
+	case strings.Contains(err.Error(), "not found"):
+		if _, ok := sc.ElasticSubnet[network.String()]; ok {
+			elasticStatus = logging.Red.Wrap("Not elastic, but local files say it was transformed")
+		}
+	default:
+		return err
+	}
+	table.Append([]string{"Elastic Status", elasticStatus})
+	table.Render()
+	return nil
+}
+
+func printChainDetails(sc models.Sidecar, network models.Network, endpoint string) error {
+	blockchainID := sc.Networks[network.String()].BlockchainID
+	if blockchainID == ids.Empty {
+		ux.Logger.PrintToUser("Chain %s has not been deployed to %s", sc.Name, network.String())
+		return nil
+	}
+	if sc.VM != models.SubnetEvm {
+		ux.Logger.PrintToUser("Chain %s runs a %s VM; live chain data is only available for Subnet-EVM", sc.Name, sc.VM)
+		return nil
+	}
+	live, err := getLiveEvmState(endpoint, blockchainID)
+	if err != nil {
+		return err
+	}
+	genesis, err := app.LoadEvmGenesis(sc.Name)
+	if err != nil {
+		return err
+	}
+	lockUpgrades, err := loadLockUpgrades(sc.Name)
+	if err != nil {
+		return err
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Chain " + sc.Name, "Value"})
+	table.SetRowLine(true)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Append([]string{"BlockchainID", blockchainID.String()})
+	table.Append([]string{"Block Height", strconv.FormatUint(live.BlockHeight, 10)})
+	table.Append([]string{"ChainID", live.ChainConfig.ChainID.String()})
+	table.Append([]string{"Gas Limit", live.FeeConfig.GasLimit.String()})
+	table.Append([]string{"Min Base Fee", live.FeeConfig.MinBaseFee.String()})
+	table.Append([]string{"Target Gas", live.FeeConfig.TargetGas.String()})
+	precompiles := []string{}
+	for key := range live.ChainConfig.GenesisPrecompiles {
+		precompiles = append(precompiles, key+" (genesis)")
+	}
+	for _, upgrade := range live.ChainConfig.UpgradeConfig.PrecompileUpgrades {
+		status := "activation"
+		if upgrade.Config.IsDisabled() {
+			status = "disable"
+		}
+		precompiles = append(precompiles, fmt.Sprintf("%s (%s at %d)", upgrade.Config.Key(), status, *upgrade.Config.Timestamp()))
+	}
+	table.Append([]string{"Precompiles", strings.Join(precompiles, "\n")})
+	table.Render()
+
+	drift := getEvmDrift(genesis, lockUpgrades, live)
+	if len(drift) == 0 {
+		ux.Logger.PrintToUser(logging.Green.Wrap("The chain matches the local genesis and upgrade files"))
+		return nil
+	}
+	ux.Logger.PrintToUser(logging.Red.Wrap("The chain differs from the local genesis and upgrade files:"))
+	for _, d := range drift {
+		ux.Logger.PrintToUser(logging.Red.Wrap("  - " + d))
+	}
+	return nil
+}
+
+func getLiveEvmState(endpoint string, blockchainID ids.ID) (liveEvmState, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.E2ERequestTimeout)
+	defer cancel()
+	state := liveEvmState{}
+	rpcURL := evm.GetRPCURL(endpoint, blockchainID)
+	client, err := rpc.DialContext(ctx, rpcURL)
+	if err != nil {
+		return state, err
+	}
+	defer client.Close()
+
+	var height string
+	if err := client.CallContext(ctx, &height, "eth_blockNumber"); err != nil {
+		return state, fmt.Errorf("failed to get block height: %w", err)
+	}
+	state.BlockHeight, err = strconv.ParseUint(strings.TrimPrefix(height, "0x"), 16, 64)
+	if err != nil {
+		return state, fmt.Errorf("invalid block height %q: %w", height, err)
+	}
+	state.ChainConfig, err = evm.GetChainConfig(rpcURL)
+	if err != nil {
+		return state, err
+	}
+	var fee liveFeeConfig
+	if err := client.CallContext(ctx, &fee, feeConfigAPI, "latest"); err != nil {
+		return state, fmt.Errorf("failed to get fee config: %w", err)
+	}
+	state.FeeConfig = fee.FeeConfig
+	return state, nil
+}
+
+// loadLockUpgrades returns the precompile upgrades that were applied to [subnetName]
+func loadLockUpgrades(subnetName string) ([]params.PrecompileUpgrade, error) {
+	lockBytes, err := app.ReadLockUpgradeFile(subnetName)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var upgrades params.UpgradeConfig
+	if err := json.Unmarshal(lockBytes, &upgrades); err != nil {
+		return nil, fmt.Errorf("failed to parse the upgrades lock file: %w", err)
+	}
+	return upgrades.PrecompileUpgrades, nil
+}
+
+// getEvmDrift lists the differences between the local [genesis] and [lockUpgrades]
+// and the [live] state of the chain
+func getEvmDrift(genesis core.Genesis, lockUpgrades []params.PrecompileUpgrade, live liveEvmState) []string {
+	drift := []string{}
+	if genesis.Config.ChainID.Cmp(live.ChainConfig.ChainID) != 0 {
+		drift = append(drift, fmt.Sprintf("chain ID is %s, local genesis has %s", live.ChainConfig.ChainID, genesis.Config.ChainID))
+	}
+	if !genesis.Config.FeeConfig.Equal(&live.FeeConfig) {
+		drift = append(drift, "fee config differs from the local genesis (it may have been changed with the fee manager precompile)")
+	}
+	for key := range genesis.Config.GenesisPrecompiles {
+		if _, ok := live.ChainConfig.GenesisPrecompiles[key]; !ok {
+			drift = append(drift, fmt.Sprintf("genesis precompile %s is not enabled on chain", key))
+		}
+	}
+	for key := range live.ChainConfig.GenesisPrecompiles {
+		if _, ok := genesis.Config.GenesisPrecompiles[key]; !ok {
+			drift = append(drift, fmt.Sprintf("genesis precompile %s is enabled on chain but missing from local genesis", key))
+		}
+	}
+	liveUpgrades := live.ChainConfig.UpgradeConfig.PrecompileUpgrades
+	for _, lu := range lockUpgrades {
+		if !containsUpgrade(liveUpgrades, lu) {
+			drift = append(drift, fmt.Sprintf("applied upgrade %s at %d is not active on chain", lu.Config.Key(), *lu.Config.Timestamp()))
+		}
+	}
+	for _, u := range liveUpgrades {
+		if !containsUpgrade(lockUpgrades, u) {
+			drift = append(drift, fmt.Sprintf("upgrade %s at %d is active on chain but missing from local upgrade files", u.Config.Key(), *u.Config.Timestamp()))
+		}
+	}
+	return drift
+}
+
+func containsUpgrade(upgrades []params.PrecompileUpgrade, upgrade params.PrecompileUpgrade) bool {
+	for _, u := range upgrades {
+		if u.Config.Key() == upgrade.Config.Key() && u.Config.Equal(upgrade.Config) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEvmDrift(t *testing.T) {
+	require := require.New(t)
+
+	ts := uint64(1000)
+	admins := []common.Address{common.HexToAddress("0x1")}
+	upgrade := params.PrecompileUpgrade{Config: txallowlist.NewConfig(&ts, admins, nil)}
+
+	genesis := core.Genesis{Config: &params.ChainConfig{
+		ChainID:   big.NewInt(1234),
+		FeeConfig: params.DefaultFeeConfig,
+	}}
+	live := liveEvmState{
+		ChainConfig: params.ChainConfigWithUpgradesJSON{
+			ChainConfig: params.ChainConfig{ChainID: big.NewInt(1234)},
+			UpgradeConfig: params.UpgradeConfig{
+				PrecompileUpgrades: []params.PrecompileUpgrade{upgrade},
+			},
+		},
+		FeeConfig: params.DefaultFeeConfig,
+	}
+
+	// in sync
+	require.Empty(getEvmDrift(genesis, []params.PrecompileUpgrade{upgrade}, live))
+
+	// upgrade active on chain but not in the lock file
+	drift := getEvmDrift(genesis, nil, live)
+	require.Len(drift, 1)
+	require.Contains(drift[0], txallowlist.ConfigKey)
+
+	// chain ID and fee config differ
+	live.ChainConfig.ChainID = big.NewInt(4321)
+	live.FeeConfig.GasLimit = big.NewInt(1)
+	drift = getEvmDrift(genesis, []params.PrecompileUpgrade{upgrade}, live)
+	require.Len(drift, 2)
+}
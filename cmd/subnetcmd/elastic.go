@@ -47,6 +47,7 @@ var (
 	tokenNameFlag       string
 	tokenSymbolFlag     string
 	useDefaultConfig    bool
+	elasticConfigFile   string
 	overrideWarning     bool
 	transformValidators bool
 	denominationFlag    int
@@ -73,6 +74,7 @@ mechanics will work.`,
 	cmd.Flags().StringVar(&tokenNameFlag, "tokenName", "", "specify the token name")
 	cmd.Flags().StringVar(&tokenSymbolFlag, "tokenSymbol", "", "specify the token symbol")
 	cmd.Flags().BoolVar(&useDefaultConfig, "default", false, "use default elastic subnet config values")
+	cmd.Flags().StringVar(&elasticConfigFile, "config", "", "file path of the elastic subnet config to use")
 	cmd.Flags().BoolVar(&overrideWarning, "force", false, "override transform into elastic subnet warning")
 	cmd.Flags().Uint64Var(&stakeAmount, "stake-amount", 0, "amount of tokens to stake on validator")
 	cmd.Flags().StringVar(&startTimeStr, "start-time", "", "start time that validator starts validating")
@@ -84,6 +86,8 @@ mechanics will work.`,
 	cmd.Flags().StringVarP(&keyName, "key", "k", "", "select the key to use [fuji only]")
 	cmd.Flags().StringSliceVar(&subnetAuthKeys, "subnet-auth-keys", nil, "control keys that will be used to authenticate the transformSubnet tx")
 	cmd.Flags().StringVar(&outputTxPath, "output-tx-path", "", "file path of the transformSubnet tx")
+	// subnet elastic status
+	cmd.AddCommand(newElasticStatusCmd())
 	return cmd
 }
 
@@ -164,6 +168,10 @@ func promptDeployFirst(cmd *cobra.Command, args []string, prompt string, err err
 func transformElasticSubnet(cmd *cobra.Command, args []string) error {
 	subnetName := args[0]
 
+	if useDefaultConfig && elasticConfigFile != "" {
+		return errors.New("--default and --config are mutually exclusive")
+	}
+
 	if !app.SubnetConfigExists(subnetName) {
 		prompt := fmt.Sprintf("Subnet %s is not created yet. Do you want to create it first?", args[0])
 		err := promptDeployFirst(cmd, args, prompt, errors.New("subnet does not exist"))
@@ -282,7 +290,12 @@ func transformElasticSubnet(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	elasticSubnetConfig, err := es.GetElasticSubnetConfig(app, tokenSymbol, useDefaultConfig)
+	var elasticSubnetConfig models.ElasticSubnetConfig
+	if elasticConfigFile != "" {
+		elasticSubnetConfig, err = es.LoadElasticSubnetConfigFile(elasticConfigFile)
+	} else {
+		elasticSubnetConfig, err = es.GetElasticSubnetConfig(app, tokenSymbol, useDefaultConfig)
+	}
 	if err != nil {
 		return err
 	}
@@ -327,7 +340,7 @@ func transformElasticSubnet(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		err = app.UpdateSidecarElasticSubnetPartialTx(&sc, network, "CreateAssetTx", assetID)
+		err = app.UpdateSidecarJournal(&sc, network, models.ElasticTransformJournal, "CreateAssetTx", assetID)
 		if err != nil {
 			return err
 		}
@@ -341,7 +354,7 @@ func transformElasticSubnet(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		err = app.UpdateSidecarElasticSubnetPartialTx(&sc, network, "ExportTx", txID)
+		err = app.UpdateSidecarJournal(&sc, network, models.ElasticTransformJournal, "ExportTx", txID)
 		if err != nil {
 			return err
 		}
@@ -356,7 +369,7 @@ func transformElasticSubnet(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		err = app.UpdateSidecarElasticSubnetPartialTx(&sc, network, "ImportTx", txID)
+		err = app.UpdateSidecarJournal(&sc, network, models.ElasticTransformJournal, "ImportTx", txID)
 		if err != nil {
 			return err
 		}
@@ -413,6 +426,9 @@ func transformElasticSubnet(cmd *cobra.Command, args []string) error {
 		if err = app.UpdateSidecarElasticSubnet(&sc, network, subnetID, assetID, txID, tokenName, tokenSymbol); err != nil {
 			return fmt.Errorf("elastic subnet transformation was successful, but failed to update sidecar: %w", err)
 		}
+		if err = app.ClearSidecarJournal(&sc, network, models.ElasticTransformJournal); err != nil {
+			return err
+		}
 		PrintTransformResults(subnetName, txID, subnetID, tokenName, tokenSymbol, assetID)
 	}
 	return nil
@@ -725,6 +741,10 @@ func checkIfTxHasOccurred(
 	network models.Network,
 	txName string,
 ) (bool, ids.ID) {
+	if txID, ok := sc.GetJournalStep(network, models.ElasticTransformJournal, txName); ok {
+		return true, txID
+	}
+	// partial txs saved by previous versions of the tool
 	if sc.ElasticSubnet == nil {
 		return false, ids.Empty
 	}
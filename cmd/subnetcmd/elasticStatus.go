@@ -0,0 +1,287 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnetcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	es "github.com/ava-labs/avalanche-cli/pkg/elasticsubnet"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	simulateSupply      bool
+	simulateConfigFile  string
+	simulateStaked      uint64
+	simulateStakePeriod time.Duration
+	simulatePeriods     int
+)
+
+// elasticStaker is a permissionless validator or delegator of an elastic subnet
+type elasticStaker struct {
+	Type   string
+	NodeID ids.NodeID
+	Stake  uint64
+	Start  time.Time
+	End    time.Time
+}
+
+// avalanche subnet elastic status
+func newElasticStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [subnetName]",
+		Short: "Show the token supply and stakers of an elastic subnet",
+		Long: `The subnet elastic status command shows the staking asset of an elastic subnet,
+its current supply compared to its maximum supply, and its permissionless validators
+and delegators, with their stake, end time and estimated reward. Estimated rewards
+are computed with the consumption rates of the elastic subnet config, before the
+delegation fee is applied.
+
+With --simulate, no network is queried. The command instead projects the supply
+growth over consecutive staking periods when --staked tokens are staked, using
+the config given with --config, or else the subnet elastic config, or else the
+default one. This helps tuning the elastic subnet config before transforming.`,
+		SilenceUsage: true,
+		RunE:         elasticStatus,
+		Args:         cobra.ExactArgs(1),
+	}
+	cmd.Flags().BoolVar(&deployLocal, "local", false, "show the status on `local` deployment")
+	cmd.Flags().BoolVar(&deployTestnet, "fuji", false, "show the status on `fuji` deployment (alias for `testnet`)")
+	cmd.Flags().BoolVar(&deployTestnet, "testnet", false, "show the status on `testnet` deployment (alias for `fuji`)")
+	cmd.Flags().BoolVar(&simulateSupply, "simulate", false, "project the supply growth instead of querying the network")
+	cmd.Flags().StringVar(&simulateConfigFile, "config", "", "file path of the elastic subnet config to simulate")
+	cmd.Flags().Uint64Var(&simulateStaked, "staked", 0, "total amount of tokens staked in the simulation")
+	cmd.Flags().DurationVar(&simulateStakePeriod, "staking-period", 0, "staking period used in the simulation (defaults to MaxStakeDuration)")
+	cmd.Flags().IntVar(&simulatePeriods, "periods", 10, "number of consecutive staking periods to simulate")
+	return cmd
+}
+
+func elasticStatus(_ *cobra.Command, args []string) error {
+	chains, err := ValidateSubnetNameAndGetChains(args)
+	if err != nil {
+		return err
+	}
+	sc, err := app.LoadSidecar(chains[0])
+	if err != nil {
+		return err
+	}
+	if simulateSupply {
+		return simulateElasticSupply(sc)
+	}
+
+	var network models.Network
+	switch {
+	case deployLocal:
+		network = models.Local
+	case deployTestnet:
+		network = models.Fuji
+	}
+	if network == models.Undefined {
+		networkStr, err := app.Prompt.CaptureList(
+			"Choose a network to show the elastic subnet status on",
+			[]string{models.Local.String(), models.Fuji.String()},
+		)
+		if err != nil {
+			return err
+		}
+		network = models.NetworkFromString(networkStr)
+	}
+	elasticSubnet, ok := sc.ElasticSubnet[network.String()]
+	if !ok {
+		return fmt.Errorf("subnet %s is not elastic on %s", sc.Name, network.String())
+	}
+	config, err := app.LoadElasticSubnetConfig(sc.Name)
+	hasConfig := err == nil
+	if !hasConfig {
+		ux.Logger.PrintToUser("No elastic subnet config found for %s. Maximum supply and rewards can't be shown", sc.Name)
+	}
+
+	endpoint, err := network.Endpoint()
+	if err != nil {
+		return err
+	}
+	pClient := platformvm.NewClient(endpoint)
+	ctx, cancel := context.WithTimeout(context.Background(), constants.RequestTimeout)
+	defer cancel()
+	supply, err := pClient.GetCurrentSupply(ctx, elasticSubnet.SubnetID)
+	if err != nil {
+		return err
+	}
+	stakers, err := getElasticStakers(pClient, elasticSubnet.SubnetID)
+	if err != nil {
+		return err
+	}
+
+	totalStake := uint64(0)
+	for _, staker := range stakers {
+		totalStake += staker.Stake
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetRowLine(true)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Append([]string{"Subnet ID", elasticSubnet.SubnetID.String()})
+	table.Append([]string{"Asset ID", elasticSubnet.AssetID.String()})
+	table.Append([]string{"Token", fmt.Sprintf("%s (%s)", elasticSubnet.TokenName, elasticSubnet.TokenSymbol)})
+	table.Append([]string{"Current Supply", ux.ConvertToStringWithThousandSeparator(supply)})
+	if hasConfig {
+		table.Append([]string{"Max Supply", ux.ConvertToStringWithThousandSeparator(config.MaxSupply)})
+		table.Append([]string{"Minted", fmt.Sprintf("%.2f%% of the mintable supply", mintedShare(config, supply))})
+	}
+	table.Append([]string{"Total Stake", ux.ConvertToStringWithThousandSeparator(totalStake)})
+	table.Render()
+
+	if len(stakers) == 0 {
+		ux.Logger.PrintToUser("No permissionless validators")
+		return nil
+	}
+	header := []string{"Type", "NodeID", "Stake", "End Time"}
+	if hasConfig {
+		header = append(header, "Estimated Reward")
+	}
+	table = tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(header)
+	table.SetRowLine(true)
+	for _, staker := range stakers {
+		row := []string{
+			staker.Type,
+			staker.NodeID.String(),
+			ux.ConvertToStringWithThousandSeparator(staker.Stake),
+			staker.End.Format(constants.TimeParseLayout),
+		}
+		if hasConfig {
+			estimated := es.NewRewardCalculator(config).Calculate(staker.End.Sub(staker.Start), staker.Stake, supply)
+			row = append(row, ux.ConvertToStringWithThousandSeparator(estimated))
+		}
+		table.Append(row)
+	}
+	table.Render()
+	return nil
+}
+
+// getElasticStakers returns the current permissionless validators of [subnetID],
+// each one followed by its delegators
+func getElasticStakers(pClient platformvm.Client, subnetID ids.ID) ([]elasticStaker, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.RequestTimeout)
+	defer cancel()
+	validators, err := pClient.GetCurrentValidators(ctx, subnetID, nil)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(validators, func(i, j int) bool {
+		return validators[i].NodeID.String() < validators[j].NodeID.String()
+	})
+	stakers := []elasticStaker{}
+	for _, validator := range validators {
+		stakers = append(stakers, newElasticStaker("Validator", validator.ClientStaker))
+		if validator.DelegatorCount == nil || *validator.DelegatorCount == 0 {
+			continue
+		}
+		// delegators are only listed when querying a single validator
+		vs, err := pClient.GetCurrentValidators(ctx, subnetID, []ids.NodeID{validator.NodeID})
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vs {
+			for _, delegator := range v.Delegators {
+				stakers = append(stakers, newElasticStaker("Delegator", delegator.ClientStaker))
+			}
+		}
+	}
+	return stakers, nil
+}
+
+func newElasticStaker(stakerType string, staker platformvm.ClientStaker) elasticStaker {
+	stake := staker.Weight
+	if staker.StakeAmount != nil {
+		stake = *staker.StakeAmount
+	}
+	return elasticStaker{
+		Type:   stakerType,
+		NodeID: staker.NodeID,
+		Stake:  stake,
+		Start:  time.Unix(int64(staker.StartTime), 0),
+		End:    time.Unix(int64(staker.EndTime), 0),
+	}
+}
+
+// mintedShare returns the percentage of the tokens that can be minted
+// by staking rewards which have already been minted
+func mintedShare(config models.ElasticSubnetConfig, supply uint64) float64 {
+	if config.MaxSupply <= config.InitialSupply {
+		return 100
+	}
+	return 100 * float64(supply-config.InitialSupply) / float64(config.MaxSupply-config.InitialSupply)
+}
+
+func simulateElasticSupply(sc models.Sidecar) error {
+	var (
+		config models.ElasticSubnetConfig
+		err    error
+	)
+	switch {
+	case simulateConfigFile != "":
+		config, err = es.LoadElasticSubnetConfigFile(simulateConfigFile)
+	case elasticSubnetConfigExists(sc.Name):
+		config, err = app.LoadElasticSubnetConfig(sc.Name)
+	default:
+		ux.Logger.PrintToUser("Simulating the default elastic subnet config")
+		config, err = es.GetElasticSubnetConfig(app, "", true)
+	}
+	if err != nil {
+		return err
+	}
+	if simulateStaked == 0 {
+		simulateStaked, err = app.Prompt.CaptureUint64("Total amount of tokens staked")
+		if err != nil {
+			return err
+		}
+	}
+	if simulateStakePeriod == 0 {
+		simulateStakePeriod = config.MaxStakeDuration
+	}
+	if simulateStaked < config.MinValidatorStake {
+		return errors.New("staked amount must be at least MinValidatorStake")
+	}
+	projections, err := es.SimulateSupply(config, config.InitialSupply, simulateStaked, simulateStakePeriod, simulatePeriods)
+	if err != nil {
+		return err
+	}
+
+	ux.Logger.PrintToUser("Supply projection for %s staked for periods of %s, with a max supply of %s",
+		ux.ConvertToStringWithThousandSeparator(simulateStaked),
+		simulateStakePeriod,
+		ux.ConvertToStringWithThousandSeparator(config.MaxSupply),
+	)
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Period", "Elapsed Days", "Minted", "Supply", "Minted Share", "Staking Yield"})
+	table.SetRowLine(true)
+	for _, projection := range projections {
+		table.Append([]string{
+			fmt.Sprint(projection.Period),
+			fmt.Sprint(int(projection.Elapsed.Hours() / 24)),
+			ux.ConvertToStringWithThousandSeparator(projection.Minted),
+			ux.ConvertToStringWithThousandSeparator(projection.Supply),
+			fmt.Sprintf("%.2f%%", mintedShare(config, projection.Supply)),
+			fmt.Sprintf("%.2f%%", 100*float64(projection.Minted)/float64(simulateStaked)),
+		})
+	}
+	table.Render()
+	return nil
+}
+
+func elasticSubnetConfigExists(subnetName string) bool {
+	_, err := os.Stat(app.GetElasticSubnetConfigPath(subnetName))
+	return err == nil
+}
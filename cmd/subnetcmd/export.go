@@ -4,15 +4,23 @@ package subnetcmd
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 
+	"github.com/ava-labs/avalanche-cli/pkg/bundle"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 
 	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/spf13/cobra"
 )
 
-var exportOutput string
+var (
+	exportOutput          string
+	exportBundle          bool
+	bundleSkipVMBinary    bool
+	bundleSkipDeployments bool
+)
 
 // avalanche subnet list
 func newExportCmd() *cobra.Command {
@@ -22,7 +30,14 @@ func newExportCmd() *cobra.Command {
 		Long: `The subnet export command write the details of an existing Subnet deploy to a file.
 
 The command prompts for an output path. You can also provide one with
-the --output flag.`,
+the --output flag.
+
+With --bundle, the command writes instead a self-contained tar.gz bundle with all
+the Subnet configuration files (genesis, sidecar, chain and subnet configs, upgrade
+files), the custom VM binary and the per-network deployment data. The VM binary and
+the deployment data can be left out with --skip-vm-binary and --skip-deployments.
+Subnets with additional chains can't be bundled. Bundles are imported with
+avalanche subnet import file.`,
 		RunE:         exportSubnet,
 		SilenceUsage: true,
 		Args:         cobra.ExactArgs(1),
@@ -39,6 +54,9 @@ the --output flag.`,
 	cmd.Flags().BoolVarP(&deployLocal, "local", "l", false, "export `local` genesis")
 	cmd.Flags().BoolVarP(&deployTestnet, "testnet", "t", false, "export `fuji` genesis")
 	cmd.Flags().BoolVarP(&deployTestnet, "fuji", "f", false, "export `fuji` genesis")
+	cmd.Flags().BoolVar(&exportBundle, "bundle", false, "export a self-contained tar.gz bundle with all the subnet files")
+	cmd.Flags().BoolVar(&bundleSkipVMBinary, "skip-vm-binary", false, "do not include the custom VM binary in the bundle")
+	cmd.Flags().BoolVar(&bundleSkipDeployments, "skip-deployments", false, "do not include the per-network deployment data in the bundle")
 	return cmd
 }
 
@@ -62,6 +80,20 @@ func exportSubnet(_ *cobra.Command, args []string) error {
 			return err
 		}
 	}
+	if exportBundle {
+		subnetName := args[0]
+		if !app.SidecarExists(subnetName) {
+			return fmt.Errorf("subnet %s does not exist", subnetName)
+		}
+		if err := bundle.Export(app, subnetName, exportOutput, bundle.ExportOptions{
+			IncludeVMBinary:    !bundleSkipVMBinary,
+			IncludeDeployments: !bundleSkipDeployments,
+		}); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Subnet bundle written to %s", exportOutput)
+		return nil
+	}
 	var network models.Network
 	if deployMainnet {
 		network = models.Mainnet
@@ -0,0 +1,306 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnetcmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	feeManagerPrecompile = "feemanager"
+
+	gasLimitFlag                 = "gas-limit"
+	targetBlockRateFlag          = "target-block-rate"
+	minBaseFeeFlag               = "min-base-fee"
+	targetGasFlag                = "target-gas"
+	baseFeeChangeDenominatorFlag = "base-fee-change-denominator"
+	minBlockGasCostFlag          = "min-block-gas-cost"
+	maxBlockGasCostFlag          = "max-block-gas-cost"
+	blockGasCostStepFlag         = "block-gas-cost-step"
+
+	customFeeConfig = "custom"
+)
+
+var (
+	feeConfigPreset string
+	// custom fee config values, by flag name
+	feeConfigValues = map[string]*uint64{}
+)
+
+// avalanche subnet fees
+func newFeesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fees",
+		Short: "Read or change the fee config of a live Subnet-EVM chain",
+		Long: `The subnet fees command suite reads and changes the fee config of a deployed
+Subnet-EVM chain through its fee manager precompile.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			if err != nil {
+				fmt.Println(err)
+			}
+		},
+	}
+	// subnet fees get
+	cmd.AddCommand(newFeesGetCmd())
+	// subnet fees set
+	cmd.AddCommand(newFeesSetCmd())
+	return cmd
+}
+
+// avalanche subnet fees get
+func newFeesGetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "get [subnetName]",
+		Short:        "Print the current fee config of a chain",
+		Long:         `The subnet fees get command prints the fee config stored in the fee manager precompile.`,
+		SilenceUsage: true,
+		RunE:         getFees,
+		Args:         cobra.ExactArgs(1),
+	}
+	addPrecompileFlags(cmd)
+	return cmd
+}
+
+// avalanche subnet fees set
+func newFeesSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set [subnetName]",
+		Short: "Change the fee config of a chain",
+		Long: fmt.Sprintf(`The subnet fees set command changes the fee config of a chain. The new config
+starts from the --preset offered by subnet create, or from the current config if no
+preset is given, and is then changed by the individual value flags. Without any of
+those flags, the new config is prompted for.
+
+The new config is validated and compared with the current one before being
+submitted. The signing key must be on the allow list of the fee manager.
+Presets: %s.`, strings.Join(vm.GetFeeConfigPresetNames(), ", ")),
+		SilenceUsage: true,
+		RunE:         setFees,
+		Args:         cobra.ExactArgs(1),
+	}
+	addPrecompileFlags(cmd)
+	cmd.Flags().StringVar(&feeConfigPreset, "preset", "", "fee config preset to start from")
+	for _, flag := range []struct {
+		name  string
+		usage string
+	}{
+		{gasLimitFlag, "gas limit of a block"},
+		{targetBlockRateFlag, "target seconds between blocks"},
+		{minBaseFeeFlag, "minimum base fee, in wei"},
+		{targetGasFlag, "target gas consumed in a 10 seconds window"},
+		{baseFeeChangeDenominatorFlag, "denominator of the base fee changes"},
+		{minBlockGasCostFlag, "minimum block gas cost"},
+		{maxBlockGasCostFlag, "maximum block gas cost"},
+		{blockGasCostStepFlag, "block gas cost change per second off the target block rate"},
+	} {
+		value := new(uint64)
+		feeConfigValues[flag.name] = value
+		cmd.Flags().Uint64Var(value, flag.name, 0, flag.usage)
+	}
+	return cmd
+}
+
+func getFees(_ *cobra.Command, args []string) error {
+	_, rpcURL, err := getPrecompileRPCURL(args[0])
+	if err != nil {
+		return err
+	}
+	feeConfig, err := evm.GetFeeConfig(rpcURL)
+	if err != nil {
+		return err
+	}
+	printFeeConfigs(feeConfig, nil)
+	return nil
+}
+
+func setFees(cmd *cobra.Command, args []string) error {
+	network, rpcURL, err := getPrecompileRPCURL(args[0])
+	if err != nil {
+		return err
+	}
+	current, err := evm.GetFeeConfig(rpcURL)
+	if err != nil {
+		return err
+	}
+	changed := map[string]uint64{}
+	for name, value := range feeConfigValues {
+		if cmd.Flags().Changed(name) {
+			changed[name] = *value
+		}
+	}
+	if feeConfigPreset == "" && len(changed) == 0 {
+		options := append(vm.GetFeeConfigPresetNames(), customFeeConfig)
+		feeConfigPreset, err = app.Prompt.CaptureList("Choose the new fee config", options)
+		if err != nil {
+			return err
+		}
+		if feeConfigPreset == customFeeConfig {
+			feeConfigPreset = ""
+			changed, err = promptFeeConfigValues()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	newConfig := copyFeeConfig(current)
+	if feeConfigPreset != "" {
+		newConfig, err = vm.GetFeeConfigPreset(feeConfigPreset)
+		if err != nil {
+			return err
+		}
+		newConfig = copyFeeConfig(newConfig)
+	}
+	setFeeConfigValues(&newConfig, changed)
+	if err := newConfig.Verify(); err != nil {
+		return fmt.Errorf("invalid fee config: %w", err)
+	}
+	if newConfig.Equal(&current) {
+		ux.Logger.PrintToUser("The new fee config is the same as the current one")
+		return nil
+	}
+	printFeeConfigs(current, &newConfig)
+	yes, err := app.Prompt.CaptureYesNo("Submit the new fee config?")
+	if err != nil {
+		return err
+	}
+	if !yes {
+		return nil
+	}
+
+	privateKey, err := getPrecompileKey(network)
+	if err != nil {
+		return err
+	}
+	if err := checkPrecompileEnabled(rpcURL, feeManagerPrecompile, privateKey); err != nil {
+		return err
+	}
+	input, err := feemanager.PackSetFeeConfig(newConfig)
+	if err != nil {
+		return err
+	}
+	receipt, err := evm.SendTx(rpcURL, privateKey, feemanager.ContractAddress, nil, input)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Fee config changed in tx %s (block %s)", receipt.TxHash, receipt.BlockNumber)
+	return nil
+}
+
+func promptFeeConfigValues() (map[string]uint64, error) {
+	values := map[string]uint64{}
+	for _, name := range getFeeConfigFlagNames() {
+		value, err := app.Prompt.CaptureUint64(fmt.Sprintf("Set %s", strings.ReplaceAll(name, "-", " ")))
+		if err != nil {
+			return nil, err
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+func getFeeConfigFlagNames() []string {
+	return []string{
+		gasLimitFlag,
+		targetBlockRateFlag,
+		minBaseFeeFlag,
+		targetGasFlag,
+		baseFeeChangeDenominatorFlag,
+		minBlockGasCostFlag,
+		maxBlockGasCostFlag,
+		blockGasCostStepFlag,
+	}
+}
+
+// copyFeeConfig returns a copy of [feeConfig] that doesn't share its big ints
+func copyFeeConfig(feeConfig commontype.FeeConfig) commontype.FeeConfig {
+	copyInt := func(i *big.Int) *big.Int {
+		if i == nil {
+			return nil
+		}
+		return new(big.Int).Set(i)
+	}
+	return commontype.FeeConfig{
+		GasLimit:                 copyInt(feeConfig.GasLimit),
+		TargetBlockRate:          feeConfig.TargetBlockRate,
+		MinBaseFee:               copyInt(feeConfig.MinBaseFee),
+		TargetGas:                copyInt(feeConfig.TargetGas),
+		BaseFeeChangeDenominator: copyInt(feeConfig.BaseFeeChangeDenominator),
+		MinBlockGasCost:          copyInt(feeConfig.MinBlockGasCost),
+		MaxBlockGasCost:          copyInt(feeConfig.MaxBlockGasCost),
+		BlockGasCostStep:         copyInt(feeConfig.BlockGasCostStep),
+	}
+}
+
+// setFeeConfigValues sets into [feeConfig] the [values] given by flag name
+func setFeeConfigValues(feeConfig *commontype.FeeConfig, values map[string]uint64) {
+	for name, value := range values {
+		bigValue := new(big.Int).SetUint64(value)
+		switch name {
+		case gasLimitFlag:
+			feeConfig.GasLimit = bigValue
+		case targetBlockRateFlag:
+			feeConfig.TargetBlockRate = value
+		case minBaseFeeFlag:
+			feeConfig.MinBaseFee = bigValue
+		case targetGasFlag:
+			feeConfig.TargetGas = bigValue
+		case baseFeeChangeDenominatorFlag:
+			feeConfig.BaseFeeChangeDenominator = bigValue
+		case minBlockGasCostFlag:
+			feeConfig.MinBlockGasCost = bigValue
+		case maxBlockGasCostFlag:
+			feeConfig.MaxBlockGasCost = bigValue
+		case blockGasCostStepFlag:
+			feeConfig.BlockGasCostStep = bigValue
+		}
+	}
+}
+
+func getFeeConfigFields(feeConfig commontype.FeeConfig) []string {
+	return []string{
+		feeConfig.GasLimit.String(),
+		strconv.FormatUint(feeConfig.TargetBlockRate, 10),
+		feeConfig.MinBaseFee.String(),
+		feeConfig.TargetGas.String(),
+		feeConfig.BaseFeeChangeDenominator.String(),
+		feeConfig.MinBlockGasCost.String(),
+		feeConfig.MaxBlockGasCost.String(),
+		feeConfig.BlockGasCostStep.String(),
+	}
+}
+
+// printFeeConfigs prints [current], and [newConfig] next to it if given
+func printFeeConfigs(current commontype.FeeConfig, newConfig *commontype.FeeConfig) {
+	table := tablewriter.NewWriter(os.Stdout)
+	header := []string{"Parameter", "Current"}
+	var newFields []string
+	if newConfig != nil {
+		header = append(header, "New")
+		newFields = getFeeConfigFields(*newConfig)
+	}
+	table.SetHeader(header)
+	table.SetRowLine(true)
+	currentFields := getFeeConfigFields(current)
+	for i, name := range getFeeConfigFlagNames() {
+		row := []string{name, currentFields[i]}
+		if newConfig != nil {
+			row = append(row, newFields[i])
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnetcmd
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetFeeConfigValues(t *testing.T) {
+	require := require.New(t)
+
+	preset, err := vm.GetFeeConfigPreset(vm.FastFeeConfig)
+	require.NoError(err)
+	feeConfig := copyFeeConfig(preset)
+	setFeeConfigValues(&feeConfig, map[string]uint64{
+		gasLimitFlag:        20_000_000,
+		targetBlockRateFlag: 1,
+	})
+	require.NoError(feeConfig.Verify())
+	require.Equal(big.NewInt(20_000_000), feeConfig.GasLimit)
+	require.Equal(uint64(1), feeConfig.TargetBlockRate)
+	require.Equal(preset.TargetGas, feeConfig.TargetGas)
+	// the preset is left untouched
+	require.Equal(vm.StarterFeeConfig.GasLimit, preset.GasLimit)
+
+	setFeeConfigValues(&feeConfig, map[string]uint64{
+		minBlockGasCostFlag: 10,
+		maxBlockGasCostFlag: 5,
+	})
+	require.Error(feeConfig.Verify())
+
+	_, err = vm.GetFeeConfigPreset("turbo")
+	require.ErrorContains(err, "invalid fee config preset")
+}
@@ -0,0 +1,291 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-network-runner/server"
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	healthEndpoints []string
+	healthMaxLag    uint64
+	healthWatch     bool
+	healthInterval  time.Duration
+)
+
+// JSON-RPC error code of calls to a method the node does not serve
+const methodNotFoundCode = -32601
+
+// health of a chain as seen by a single node
+type nodeHealth struct {
+	Node      string
+	Healthy   bool
+	Height    uint64
+	BlockTime time.Time
+	Pending   uint64
+	Queued    uint64
+	// the node does not serve txpool_status, which is not in the default eth-apis
+	TxPoolUnavailable bool
+	Lagging           bool
+	Err               error
+}
+
+// avalanche subnet health
+func newHealthCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "health [subnetName]",
+		Short: "Check that the chains of a deployed subnet are healthy and producing blocks",
+		Long: `The subnet health command queries, on every node endpoint, the health API of the
+subnet chains. For Subnet-EVM chains it also gets the block height, the timestamp of
+the last block and the size of the tx pool, and flags the nodes that lag behind the tip.
+
+On the local network all the nodes of the network are queried. On Fuji and Mainnet
+the public API is queried, unless node endpoints are given with --endpoint.`,
+		SilenceUsage: true,
+		RunE:         subnetHealth,
+		Args:         cobra.ExactArgs(1),
+	}
+	cmd.Flags().BoolVar(&deployLocal, "local", false, "check the health on `local` deployment")
+	cmd.Flags().BoolVar(&deployTestnet, "fuji", false, "check the health on `fuji` deployment (alias for `testnet`)")
+	cmd.Flags().BoolVar(&deployTestnet, "testnet", false, "check the health on `testnet` deployment (alias for `fuji`)")
+	cmd.Flags().BoolVar(&deployMainnet, "mainnet", false, "check the health on `mainnet` deployment")
+	cmd.Flags().StringSliceVar(&healthEndpoints, "endpoint", nil, "node API endpoints to query (fuji/mainnet)")
+	cmd.Flags().Uint64Var(&healthMaxLag, "max-lag", 5, "number of blocks a node can be behind the tip before being flagged")
+	cmd.Flags().BoolVar(&healthWatch, "watch", false, "keep refreshing the health until interrupted")
+	cmd.Flags().DurationVar(&healthInterval, "interval", 5*time.Second, "refresh interval used with --watch")
+	return cmd
+}
+
+func subnetHealth(_ *cobra.Command, args []string) error {
+	var network models.Network
+	switch {
+	case deployLocal:
+		network = models.Local
+	case deployTestnet:
+		network = models.Fuji
+	case deployMainnet:
+		network = models.Mainnet
+	}
+	if network == models.Undefined {
+		networkStr, err := app.Prompt.CaptureList(
+			"Choose a network to check the subnet health on",
+			[]string{models.Local.String(), models.Fuji.String(), models.Mainnet.String()},
+		)
+		if err != nil {
+			return err
+		}
+		network = models.NetworkFromString(networkStr)
+	}
+
+	chains, err := ValidateSubnetNameAndGetChains(args)
+	if err != nil {
+		return err
+	}
+	sc, err := app.LoadSidecar(chains[0])
+	if err != nil {
+		return err
+	}
+	subnetID := sc.Networks[network.String()].SubnetID
+	if subnetID == ids.Empty {
+		return fmt.Errorf("%s has not been deployed to %s", sc.Name, network.String())
+	}
+	sidecars := []models.Sidecar{sc}
+	for _, chain := range sc.Chains {
+		chainSC, err := app.LoadSidecar(chain)
+		if err != nil {
+			return err
+		}
+		if chainSC.Networks[network.String()].BlockchainID != ids.Empty {
+			sidecars = append(sidecars, chainSC)
+		}
+	}
+
+	endpoints, err := getHealthEndpoints(network)
+	if err != nil {
+		return err
+	}
+
+	for {
+		for _, chainSC := range sidecars {
+			blockchainID := chainSC.Networks[network.String()].BlockchainID
+			results := getChainHealth(endpoints, subnetID, blockchainID, chainSC.VM == models.SubnetEvm)
+			markLaggingNodes(results, healthMaxLag)
+			printChainHealth(chainSC.Name, blockchainID, results, chainSC.VM == models.SubnetEvm)
+		}
+		if !healthWatch {
+			return nil
+		}
+		time.Sleep(healthInterval)
+		ux.Logger.PrintToUser("")
+	}
+}
+
+// getHealthEndpoints returns the API endpoints to query, by node name
+func getHealthEndpoints(network models.Network) (map[string]string, error) {
+	endpoints := map[string]string{}
+	if network != models.Local {
+		if len(healthEndpoints) == 0 {
+			endpoint, err := network.Endpoint()
+			if err != nil {
+				return nil, err
+			}
+			healthEndpoints = []string{endpoint}
+		}
+		for _, endpoint := range healthEndpoints {
+			endpoints[endpoint] = endpoint
+		}
+		return endpoints, nil
+	}
+	cli, err := binutils.NewGRPCClient()
+	if err != nil {
+		return nil, err
+	}
+	status, err := cli.Status(binutils.GetAsyncContext())
+	if err != nil {
+		if server.IsServerError(err, server.ErrNotBootstrapped) {
+			return nil, errors.New("no local network running")
+		}
+		return nil, err
+	}
+	for name, nodeInfo := range status.GetClusterInfo().GetNodeInfos() {
+		endpoints[name] = nodeInfo.GetUri()
+	}
+	return endpoints, nil
+}
+
+func getChainHealth(endpoints map[string]string, subnetID, blockchainID ids.ID, isEVM bool) []nodeHealth {
+	results := []nodeHealth{}
+	for node, endpoint := range endpoints {
+		results = append(results, getNodeHealth(node, endpoint, subnetID, blockchainID, isEVM))
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Node < results[j].Node
+	})
+	return results
+}
+
+func getNodeHealth(node, endpoint string, subnetID, blockchainID ids.ID, isEVM bool) nodeHealth {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.E2ERequestTimeout)
+	defer cancel()
+	result := nodeHealth{Node: node}
+
+	reply, err := health.NewClient(endpoint).Health(ctx, []string{subnetID.String()})
+	if err != nil {
+		result.Err = fmt.Errorf("health API: %w", err)
+		return result
+	}
+	check, ok := reply.Checks[blockchainID.String()]
+	result.Healthy = ok && check.Error == nil
+	if !isEVM {
+		return result
+	}
+
+	rpcClient, err := rpc.DialContext(ctx, fmt.Sprintf("%s/ext/bc/%s/rpc", endpoint, blockchainID))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer rpcClient.Close()
+	header, err := ethclient.NewClient(rpcClient).HeaderByNumber(ctx, nil)
+	if err != nil {
+		result.Err = fmt.Errorf("last block: %w", err)
+		return result
+	}
+	result.Height = header.Number.Uint64()
+	result.BlockTime = time.Unix(int64(header.Time), 0)
+	var txPoolStatus map[string]hexutil.Uint
+	if err := rpcClient.CallContext(ctx, &txPoolStatus, "txpool_status"); err != nil {
+		if isMethodNotFound(err) {
+			result.TxPoolUnavailable = true
+			return result
+		}
+		result.Err = fmt.Errorf("tx pool: %w", err)
+		return result
+	}
+	result.Pending = uint64(txPoolStatus["pending"])
+	result.Queued = uint64(txPoolStatus["queued"])
+	return result
+}
+
+// isMethodNotFound tells if [err] is the JSON-RPC error of a method the
+// node does not serve
+func isMethodNotFound(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundCode
+}
+
+// markLaggingNodes flags the nodes whose height is more than [maxLag]
+// blocks behind the highest height seen
+func markLaggingNodes(results []nodeHealth, maxLag uint64) {
+	tip := uint64(0)
+	for _, result := range results {
+		if result.Err == nil && result.Height > tip {
+			tip = result.Height
+		}
+	}
+	for i := range results {
+		results[i].Lagging = results[i].Err == nil && tip-results[i].Height > maxLag
+	}
+}
+
+func printChainHealth(chainName string, blockchainID ids.ID, results []nodeHealth, isEVM bool) {
+	ux.Logger.PrintToUser("Chain %s (%s) at %s", chainName, blockchainID, time.Now().Format(time.RFC3339))
+	table := tablewriter.NewWriter(os.Stdout)
+	header := []string{"Node", "Healthy"}
+	if isEVM {
+		header = append(header, "Height", "Last Block", "Pending Txs", "Queued Txs")
+	}
+	header = append(header, "Status")
+	table.SetHeader(header)
+	table.SetRowLine(true)
+
+	for _, result := range results {
+		row := []string{result.Node, strconv.FormatBool(result.Healthy)}
+		if isEVM {
+			row = append(row, "-", "-", "-", "-")
+			if result.Err == nil {
+				row[2] = strconv.FormatUint(result.Height, 10)
+				row[3] = fmt.Sprintf("%s ago", time.Since(result.BlockTime).Round(time.Second))
+				if result.TxPoolUnavailable {
+					row[4] = "tx pool unavailable"
+					row[5] = "tx pool unavailable"
+				} else {
+					row[4] = strconv.FormatUint(result.Pending, 10)
+					row[5] = strconv.FormatUint(result.Queued, 10)
+				}
+			}
+		}
+		status := logging.Green.Wrap("OK")
+		switch {
+		case result.Err != nil:
+			status = logging.Red.Wrap(result.Err.Error())
+		case !result.Healthy:
+			status = logging.Red.Wrap("unhealthy")
+		case result.Lagging:
+			status = logging.Red.Wrap("lagging behind the tip")
+		}
+		row = append(row, status)
+		table.Append(row)
+	}
+	table.Render()
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkLaggingNodes(t *testing.T) {
+	require := require.New(t)
+
+	results := []nodeHealth{
+		{Node: "node1", Height: 100},
+		{Node: "node2", Height: 95},
+		{Node: "node3", Height: 94},
+		// unreachable nodes neither set the tip nor are flagged
+		{Node: "node4", Height: 1000, Err: errors.New("unreachable")},
+	}
+	markLaggingNodes(results, 5)
+	require.False(results[0].Lagging)
+	require.False(results[1].Lagging)
+	require.True(results[2].Lagging)
+	require.False(results[3].Lagging)
+}
+
+type testRPCError struct {
+	code int
+}
+
+func (e testRPCError) Error() string  { return "rpc error" }
+func (e testRPCError) ErrorCode() int { return e.code }
+
+func TestIsMethodNotFound(t *testing.T) {
+	require := require.New(t)
+	require.True(isMethodNotFound(testRPCError{code: methodNotFoundCode}))
+	require.True(isMethodNotFound(fmt.Errorf("wrapped: %w", testRPCError{code: methodNotFoundCode})))
+	require.False(isMethodNotFound(testRPCError{code: -32000}))
+	require.False(isMethodNotFound(errors.New("connection refused")))
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 
 	"github.com/ava-labs/avalanche-cli/pkg/apmintegration"
+	"github.com/ava-labs/avalanche-cli/pkg/bundle"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
@@ -31,7 +32,8 @@ func newImportFileCmd() *cobra.Command {
 		RunE:         importSubnet,
 		SilenceUsage: true,
 		Args:         cobra.MaximumNArgs(1),
-		Long: `The subnet import command will import a subnet configuration from a file or a git repository.
+		Long: `The subnet import command will import a subnet configuration from a file, a bundle
+written by subnet export --bundle, or a git repository.
 
 To import from a file, you can optionally provide the path as a command-line argument.
 Alternatively, running the command without any arguments triggers an interactive wizard.
@@ -102,6 +104,22 @@ func importFromFile(importPath string) error {
 		}
 	}
 
+	isBundle, err := bundle.IsBundle(importPath)
+	if err != nil {
+		return err
+	}
+	if isBundle {
+		manifest, err := bundle.Import(app, importPath, overwriteImport)
+		if errors.Is(err, bundle.ErrSubnetExists) {
+			return errors.New("subnet already exists. Use --" + forceFlag + " parameter to overwrite")
+		}
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Subnet %s imported successfully from bundle (%d files)", manifest.SubnetName, len(manifest.Files))
+		return nil
+	}
+
 	importFileBytes, err := os.ReadFile(importPath)
 	if err != nil {
 		return err
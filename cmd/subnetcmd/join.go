@@ -270,13 +270,15 @@ func joinCmd(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	vmPath, err := plugins.CreatePlugin(app, sc.Name, pluginDir)
-	if err != nil {
-		return err
+	// every blockchain of the subnet needs its VM installed
+	for _, chain := range chains {
+		vmPath, err := plugins.CreatePlugin(app, chain, pluginDir)
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("VM binary written to %s", vmPath)
 	}
 
-	ux.Logger.PrintToUser("VM binary written to %s", vmPath)
-
 	if err := plugins.EditConfigFile(app, subnetIDStr, networkLower, avagoConfigPath, forceWrite); err != nil {
 		return err
 	}
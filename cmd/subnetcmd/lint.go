@@ -0,0 +1,135 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintMainnet bool
+	lintJSON    bool
+)
+
+// avalanche subnet lint
+func newLintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint [subnetName]",
+		Short: "Check a Subnet-EVM genesis for questionable settings",
+		Long: `The subnet lint command looks for settings of a Subnet-EVM genesis that pass
+verification but are likely mistakes: a minimum base fee low enough to spam the chain,
+a target gas inconsistent with the gas limit, a transaction allow list without funded
+addresses, a native minter without admin, a chain ID used by another local subnet or a
+well-known EVM chain, or an airdrop to the default test key in the Mainnet genesis.
+
+Each issue has a severity of error, warning or info. The command fails if any error is
+found. With --mainnet, the Mainnet genesis is checked, as done before deploy --mainnet.
+With --json, the issues are printed as a JSON list.`,
+		SilenceUsage: true,
+		RunE:         lintSubnetCmd,
+		Args:         cobra.ExactArgs(1),
+	}
+	cmd.Flags().BoolVar(&lintMainnet, "mainnet", false, "check the genesis used on mainnet")
+	cmd.Flags().BoolVar(&lintJSON, "json", false, "print the issues as JSON")
+	return cmd
+}
+
+func lintSubnetCmd(_ *cobra.Command, args []string) error {
+	subnetName := args[0]
+	network := models.Fuji
+	if lintMainnet {
+		network = models.Mainnet
+	}
+	issues, err := lintSubnet(subnetName, network)
+	if err != nil {
+		return err
+	}
+	if lintJSON {
+		issuesBytes, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(issuesBytes))
+	} else {
+		printLintIssues(issues)
+	}
+	if vm.HasLintErrors(issues) {
+		return fmt.Errorf("the genesis of %s has lint errors", subnetName)
+	}
+	return nil
+}
+
+// lintSubnet lints the genesis of [subnetName] used on [network]
+func lintSubnet(subnetName string, network models.Network) ([]vm.LintIssue, error) {
+	if !app.SidecarExists(subnetName) || !app.GenesisExists(subnetName) {
+		return nil, fmt.Errorf("subnet %s does not exist", subnetName)
+	}
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return nil, err
+	}
+	if sc.VM != models.SubnetEvm {
+		return nil, errors.New("only Subnet-EVM genesis can be linted")
+	}
+	genesisBytes, err := app.LoadRawGenesis(subnetName, network)
+	if err != nil {
+		return nil, err
+	}
+	var genesis core.Genesis
+	if err := json.Unmarshal(genesisBytes, &genesis); err != nil {
+		return nil, fmt.Errorf("failed to parse the genesis of %s: %w", subnetName, err)
+	}
+	otherChainIDs, err := getOtherChainIDs(subnetName)
+	if err != nil {
+		return nil, err
+	}
+	return vm.LintEvmGenesis(genesis, otherChainIDs, network == models.Mainnet), nil
+}
+
+// getOtherChainIDs returns the chain IDs of the Subnet-EVM subnets other than
+// [subnetName], by subnet name
+func getOtherChainIDs(subnetName string) (map[string]*big.Int, error) {
+	cars, err := getSidecars(app)
+	if err != nil {
+		return nil, err
+	}
+	chainIDs := map[string]*big.Int{}
+	for _, sc := range cars {
+		if sc.Name == subnetName || sc.VM != models.SubnetEvm {
+			continue
+		}
+		chainIDStr := sc.ChainID
+		if chainIDStr == "" {
+			chainIDStr = getGenesisChainID(*sc)
+		}
+		if chainID, ok := new(big.Int).SetString(chainIDStr, 10); ok {
+			chainIDs[sc.Name] = chainID
+		}
+	}
+	return chainIDs, nil
+}
+
+func printLintIssues(issues []vm.LintIssue) {
+	if len(issues) == 0 {
+		ux.Logger.PrintToUser("No issues found")
+		return
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Severity", "Rule", "Message"})
+	table.SetRowLine(true)
+	for _, issue := range issues {
+		table.Append([]string{string(issue.Severity), issue.Rule, issue.Message})
+	}
+	table.Render()
+}
@@ -73,11 +73,7 @@ func listSubnets(cmd *cobra.Command, args []string) error {
 		// for older sidecars, check in genesis if sidecar has
 		// no chainID set
 		if chainID == "" {
-			sc, err := app.LoadEvmGenesis(sc.Name)
-			// ignore the error in this case: just leave it to ""
-			if err == nil {
-				chainID = sc.Config.ChainID.String()
-			}
+			chainID = getGenesisChainID(*sc)
 		}
 
 		vmID := sc.ImportedVMID
@@ -232,3 +228,19 @@ func listDeployInfo(*cobra.Command, []string) error {
 
 	return nil
 }
+
+// getGenesisChainID returns the chain ID found in the genesis of [sc],
+// or "" if it can't be found
+func getGenesisChainID(sc models.Sidecar) string {
+	genesis, err := os.ReadFile(app.GetGenesisPath(sc.Name))
+	if err != nil {
+		return ""
+	}
+	describer, err := getDescriber(sc)
+	if err != nil {
+		return ""
+	}
+	// ignore the error in this case: just leave it to ""
+	chainID, _ := describer.GetChainID(genesis)
+	return chainID
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnetcmd
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/spf13/cobra"
+)
+
+const minterPrecompile = "minter"
+
+var (
+	mintTo     string
+	mintAmount string
+)
+
+// avalanche subnet mint
+func newMintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mint [subnetName]",
+		Short: "Mint native tokens on a live Subnet-EVM chain",
+		Long: `The subnet mint command mints --amount native tokens of a deployed Subnet-EVM
+chain to the --to address, by calling the native minter precompile. The signing
+key must be on the allow list of the native minter.`,
+		SilenceUsage: true,
+		RunE:         mint,
+		Args:         cobra.ExactArgs(1),
+	}
+	addPrecompileFlags(cmd)
+	cmd.Flags().StringVar(&mintTo, "to", "", "address that receives the minted tokens")
+	cmd.Flags().StringVar(&mintAmount, "amount", "", "amount of tokens to mint, in whole tokens (10^18 units)")
+	return cmd
+}
+
+func mint(_ *cobra.Command, args []string) error {
+	to, err := parseAddress(mintTo, "Address to mint to")
+	if err != nil {
+		return err
+	}
+	var amount *big.Int
+	if mintAmount == "" {
+		amount, err = app.Prompt.CapturePositiveBigInt("Amount of tokens to mint")
+		if err != nil {
+			return err
+		}
+	} else {
+		var ok bool
+		amount, ok = new(big.Int).SetString(mintAmount, 10)
+		if !ok || amount.Sign() <= 0 {
+			return fmt.Errorf("invalid amount %q, expected a positive integer", mintAmount)
+		}
+	}
+	tokens := amount.String()
+	amount.Mul(amount, big.NewInt(params.Ether))
+
+	network, rpcURL, err := getPrecompileRPCURL(args[0])
+	if err != nil {
+		return err
+	}
+	privateKey, err := getPrecompileKey(network)
+	if err != nil {
+		return err
+	}
+	if err := checkPrecompileEnabled(rpcURL, minterPrecompile, privateKey); err != nil {
+		return err
+	}
+	input, err := nativeminter.PackMintInput(to, amount)
+	if err != nil {
+		return err
+	}
+	receipt, err := evm.SendTx(rpcURL, privateKey, nativeminter.ContractAddress, nil, input)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Minted %s tokens to %s in tx %s (block %s)", tokens, to, receipt.TxHash, receipt.BlockNumber)
+	return nil
+}
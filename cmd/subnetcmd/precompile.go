@@ -0,0 +1,161 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnetcmd
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/cmd/flags"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/key"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	precompileKeyName string
+	precompileRPCURL  string
+)
+
+// avalanche subnet precompile
+func newPrecompileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "precompile",
+		Short: "Administer the precompiles of a live Subnet-EVM chain",
+		Long: `The subnet precompile command suite calls the precompile contracts of a deployed
+Subnet-EVM chain over its RPC, signing the txs with a stored key. On Fuji and Mainnet,
+the RPC URL of a node that tracks the chain must be given with --rpc.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			if err != nil {
+				fmt.Println(err)
+			}
+		},
+	}
+	// subnet precompile allowlist
+	cmd.AddCommand(newPrecompileAllowListCmd())
+	return cmd
+}
+
+// addPrecompileFlags adds the network, RPC and key flags used by the commands that
+// call precompiles
+func addPrecompileFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&deployLocal, "local", false, "use the `local` deployment")
+	cmd.Flags().BoolVar(&deployTestnet, "fuji", false, "use the `fuji` deployment (alias for `testnet`)")
+	cmd.Flags().BoolVar(&deployTestnet, "testnet", false, "use the `testnet` deployment (alias for `fuji`)")
+	cmd.Flags().BoolVar(&deployMainnet, "mainnet", false, "use the `mainnet` deployment")
+	cmd.Flags().StringVarP(&precompileKeyName, "key", "k", "", "stored key used to sign the txs (defaults to ewoq on local)")
+	cmd.Flags().StringVar(&precompileRPCURL, "rpc", "", "RPC URL of the chain on a node that tracks it (required on fuji and mainnet)")
+}
+
+// getPrecompileRPCURL returns the network selected by the flags, or prompted
+// for, and the RPC URL of the Subnet-EVM chain [subnetName] on it. The public
+// API nodes don't serve subnet chains, so the URL must be given with --rpc
+// on fuji and mainnet
+func getPrecompileRPCURL(subnetName string) (models.Network, string, error) {
+	if !flags.EnsureMutuallyExclusive([]bool{deployLocal, deployTestnet, deployMainnet}) {
+		return models.Undefined, "", errMutuallyExlusiveNetworks
+	}
+	var network models.Network
+	switch {
+	case deployLocal:
+		network = models.Local
+	case deployTestnet:
+		network = models.Fuji
+	case deployMainnet:
+		network = models.Mainnet
+	}
+	if network == models.Undefined {
+		networkStr, err := app.Prompt.CaptureList(
+			"Choose the network of the chain",
+			[]string{models.Local.String(), models.Fuji.String(), models.Mainnet.String()},
+		)
+		if err != nil {
+			return models.Undefined, "", err
+		}
+		network = models.NetworkFromString(networkStr)
+	}
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return models.Undefined, "", err
+	}
+	if sc.VM != models.SubnetEvm {
+		return models.Undefined, "", fmt.Errorf("precompiles are only available on Subnet-EVM chains, %s is a %s chain", sc.Name, sc.VM)
+	}
+	blockchainID := sc.Networks[network.String()].BlockchainID
+	if blockchainID == ids.Empty {
+		return models.Undefined, "", fmt.Errorf("%s has not been deployed to %s", sc.Name, network.String())
+	}
+	if precompileRPCURL != "" {
+		return network, precompileRPCURL, nil
+	}
+	if network != models.Local {
+		return models.Undefined, "", fmt.Errorf("the public API doesn't serve %s, provide the RPC URL of a node that tracks it with --rpc", sc.Name)
+	}
+	endpoint, err := network.Endpoint()
+	if err != nil {
+		return models.Undefined, "", err
+	}
+	return network, evm.GetRPCURL(endpoint, blockchainID), nil
+}
+
+// getPrecompileKey returns the private key used to sign the precompile txs on [network]
+func getPrecompileKey(network models.Network) (*ecdsa.PrivateKey, error) {
+	networkID, err := network.NetworkID()
+	if err != nil {
+		return nil, err
+	}
+	if precompileKeyName == "" && network == models.Local {
+		sk, err := key.NewSoft(networkID, key.WithPrivateKeyEncoded(key.EwoqPrivateKey))
+		if err != nil {
+			return nil, err
+		}
+		return sk.Key().ToECDSA(), nil
+	}
+	if precompileKeyName == "" {
+		precompileKeyName, err = prompts.GetKeyName(app.Prompt, "sign the txs", app.GetKeyDir())
+		if err != nil {
+			return nil, err
+		}
+	}
+	sk, err := key.LoadSoft(networkID, app.GetKeyPath(precompileKeyName))
+	if err != nil {
+		return nil, err
+	}
+	return sk.Key().ToECDSA(), nil
+}
+
+// checkPrecompileEnabled fails with a clear error if the address of [privateKey]
+// isn't allowed to call the precompile [precompileName]
+func checkPrecompileEnabled(rpcURL string, precompileName string, privateKey *ecdsa.PrivateKey) error {
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	role, err := evm.GetAllowListRole(rpcURL, evm.AllowListPrecompiles[precompileName], address)
+	if errors.Is(err, evm.ErrPrecompileNotActivated) {
+		return fmt.Errorf("the %s precompile is not activated on this chain", precompileName)
+	}
+	if err != nil {
+		return err
+	}
+	if role == evm.NoRole {
+		return fmt.Errorf("%s has no role on the %s allow list. Add it with `avalanche subnet precompile allowlist`", address, precompileName)
+	}
+	return nil
+}
+
+// parseAddress parses [addressStr], or prompts for the address when it is empty
+func parseAddress(addressStr string, promptStr string) (common.Address, error) {
+	if addressStr == "" {
+		return app.Prompt.CaptureAddress(promptStr)
+	}
+	if !common.IsHexAddress(addressStr) {
+		return common.Address{}, fmt.Errorf("invalid address %q", addressStr)
+	}
+	return common.HexToAddress(addressStr), nil
+}
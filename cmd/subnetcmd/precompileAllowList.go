@@ -0,0 +1,114 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnetcmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+const (
+	addToAllowList      = "add"
+	removeFromAllowList = "remove"
+	readAllowList       = "read"
+)
+
+var (
+	allowListAddress    string
+	allowListPrecompile string
+	allowListRole       string
+)
+
+// avalanche subnet precompile allowlist
+func newPrecompileAllowListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "allowlist [subnetName] [add|remove|read]",
+		Short: "Change or read the roles of an allow list precompile",
+		Long: `The subnet precompile allowlist command manages the allow list of a precompile of
+a live Subnet-EVM chain. add gives --role to --address, remove takes any role away
+from it, and read prints its current role.
+
+The signing key must have the admin role on the precompile to add or remove
+addresses, or the manager role to change enabled addresses on Subnet-EVM versions
+that support it. The manager role is rejected by older versions.`,
+		SilenceUsage: true,
+		RunE:         precompileAllowList,
+		Args:         cobra.ExactArgs(2),
+	}
+	addPrecompileFlags(cmd)
+	cmd.Flags().StringVar(&allowListAddress, "address", "", "address to add, remove or read")
+	cmd.Flags().StringVar(&allowListPrecompile, "precompile", "",
+		fmt.Sprintf("precompile whose allow list to use [%s]", strings.Join(evm.GetAllowListPrecompileNames(), ", ")))
+	cmd.Flags().StringVar(&allowListRole, "role", evm.EnabledRole,
+		fmt.Sprintf("role given by add [%s, %s, %s]", evm.AdminRole, evm.EnabledRole, evm.ManagerRole))
+	return cmd
+}
+
+func precompileAllowList(_ *cobra.Command, args []string) error {
+	action := args[1]
+	switch action {
+	case addToAllowList, removeFromAllowList, readAllowList:
+	default:
+		return fmt.Errorf("invalid allow list action %q, expected one of %s, %s, %s",
+			action, addToAllowList, removeFromAllowList, readAllowList)
+	}
+	role := evm.NoRole
+	if action == addToAllowList {
+		role = allowListRole
+		if role != evm.AdminRole && role != evm.EnabledRole && role != evm.ManagerRole {
+			return fmt.Errorf("invalid role %q, expected one of %s, %s, %s", role, evm.AdminRole, evm.EnabledRole, evm.ManagerRole)
+		}
+	}
+
+	if allowListPrecompile == "" {
+		var err error
+		allowListPrecompile, err = app.Prompt.CaptureList("Choose the precompile", evm.GetAllowListPrecompileNames())
+		if err != nil {
+			return err
+		}
+	}
+	precompileAddress, ok := evm.AllowListPrecompiles[allowListPrecompile]
+	if !ok {
+		return fmt.Errorf("invalid precompile %q, expected one of %s",
+			allowListPrecompile, strings.Join(evm.GetAllowListPrecompileNames(), ", "))
+	}
+	address, err := parseAddress(allowListAddress, "Address")
+	if err != nil {
+		return err
+	}
+
+	network, rpcURL, err := getPrecompileRPCURL(args[0])
+	if err != nil {
+		return err
+	}
+
+	if action == readAllowList {
+		currentRole, err := evm.GetAllowListRole(rpcURL, precompileAddress, address)
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Role of %s on the %s allow list: %s", address, allowListPrecompile, currentRole)
+		return nil
+	}
+
+	input, err := evm.PackSetAllowListRole(address, role)
+	if err != nil {
+		return err
+	}
+	privateKey, err := getPrecompileKey(network)
+	if err != nil {
+		return err
+	}
+	receipt, err := evm.SendTx(rpcURL, privateKey, precompileAddress, nil, input)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Role of %s on the %s allow list set to %s in tx %s (block %s)",
+		address, allowListPrecompile, role, receipt.TxHash, receipt.BlockNumber)
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/spf13/cobra"
+)
+
+var forceRename bool
+
+// avalanche subnet rename
+func newRenameCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rename [subnetName] [newSubnetName]",
+		Short: "Rename a subnet configuration",
+		Long: `The subnet rename command renames an existing subnet configuration,
+moving its genesis, sidecar, chain and subnet configs, upgrade files and custom
+VM binary to the new name.
+
+The VMID of a subnet is derived from its name. Renaming a subnet that has
+already been deployed changes its VMID, so the existing deployments won't
+be usable from the CLI anymore. The command refuses to rename deployed subnets,
+unless --force is given.`,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(2),
+		RunE:         renameSubnet,
+	}
+	cmd.Flags().BoolVarP(&forceRename, forceFlag, "f", false, "rename even if the subnet is already deployed")
+	return cmd
+}
+
+func renameSubnet(_ *cobra.Command, args []string) error {
+	oldName := args[0]
+	newName := args[1]
+
+	if !app.SidecarExists(oldName) {
+		return fmt.Errorf("subnet %s does not exist", oldName)
+	}
+	if app.SidecarExists(newName) || app.GenesisExists(newName) {
+		return fmt.Errorf("a configuration named %s already exists", newName)
+	}
+	if err := checkInvalidSubnetNames(newName); err != nil {
+		return fmt.Errorf("subnet name %q is invalid: %w", newName, err)
+	}
+
+	sc, err := app.LoadSidecar(oldName)
+	if err != nil {
+		return err
+	}
+
+	// imported subnets keep their VMID, as it is not derived from the name
+	if !sc.ImportedFromAPM && isDeployed(sc) {
+		ux.Logger.PrintToUser(logging.Red.Wrap(
+			fmt.Sprintf("WARNING: %s is already deployed. Renaming it changes its VMID, and its existing deployments won't work with the new name", oldName),
+		))
+		if !forceRename {
+			return errors.New("refusing to rename a deployed subnet. Use --" + forceFlag + " to rename anyway")
+		}
+	}
+
+	if err := os.Rename(
+		filepath.Join(app.GetSubnetDir(), oldName),
+		filepath.Join(app.GetSubnetDir(), newName),
+	); err != nil {
+		return err
+	}
+
+	if sc.VM == models.CustomVM {
+		if err := os.Rename(app.GetCustomVMPath(oldName), app.GetCustomVMPath(newName)); err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			app.Log.Warn("tried to rename custom VM path but it actually does not exist. Ignoring")
+		}
+	}
+
+	if sc.Subnet == oldName || sc.Subnet == "" {
+		sc.Subnet = newName
+	}
+	sc.Name = newName
+	if err := app.UpdateSidecar(&sc); err != nil {
+		return err
+	}
+
+	if err := renameSubnetReferences(sc, oldName, newName); err != nil {
+		return err
+	}
+
+	ux.Logger.PrintToUser("Successfully renamed subnet configuration %s to %s", oldName, newName)
+	return nil
+}
+
+// renameSubnetReferences updates the sidecars of the chains of the subnet, or
+// the sidecar of its parent subnet, to point to the renamed configuration
+func renameSubnetReferences(sc models.Sidecar, oldName string, newName string) error {
+	if sc.IsSubnetChain() {
+		parentSC, err := app.LoadSidecar(sc.Subnet)
+		if err != nil {
+			return err
+		}
+		for i, chain := range parentSC.Chains {
+			if chain == oldName {
+				parentSC.Chains[i] = newName
+			}
+		}
+		return app.UpdateSidecar(&parentSC)
+	}
+	for _, chain := range sc.Chains {
+		chainSC, err := app.LoadSidecar(chain)
+		if err != nil {
+			return err
+		}
+		chainSC.Subnet = newName
+		if err := app.UpdateSidecar(&chainSC); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isDeployed(sc models.Sidecar) bool {
+	for _, network := range sc.Networks {
+		if network.BlockchainID != ids.Empty || network.SubnetID != ids.Empty {
+			return true
+		}
+	}
+	return len(sc.ElasticSubnet) > 0
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnetcmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanche-cli/cmd/flags"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+const rewardManagerPrecompile = "rewardmanager"
+
+var (
+	allowFeeRecipients  bool
+	rewardAddressStr    string
+	disableRewards      bool
+	rewardRoleAddresses []string
+)
+
+// avalanche subnet rewards
+func newRewardsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rewards",
+		Short: "Read or change how the block fees of a live Subnet-EVM chain are rewarded",
+		Long: `The subnet rewards command suite reads and changes the reward behavior of a deployed
+Subnet-EVM chain through its reward manager precompile.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			if err != nil {
+				fmt.Println(err)
+			}
+		},
+	}
+	// subnet rewards get
+	cmd.AddCommand(newRewardsGetCmd())
+	// subnet rewards set
+	cmd.AddCommand(newRewardsSetCmd())
+	return cmd
+}
+
+// avalanche subnet rewards get
+func newRewardsGetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get [subnetName]",
+		Short: "Print the reward mode of a chain",
+		Long: `The subnet rewards get command prints the current reward mode of a chain, and the
+current allow list role of the admin and enabled addresses of the reward manager set
+at genesis or by upgrades, and of any --address given.`,
+		SilenceUsage: true,
+		RunE:         getRewards,
+		Args:         cobra.ExactArgs(1),
+	}
+	addPrecompileFlags(cmd)
+	cmd.Flags().StringSliceVar(&rewardRoleAddresses, "address", nil, "additional addresses whose role to show")
+	return cmd
+}
+
+// avalanche subnet rewards set
+func newRewardsSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set [subnetName]",
+		Short: "Change the reward mode of a chain",
+		Long: `The subnet rewards set command changes how the block fees of a chain are rewarded:
+--allow-fee-recipients lets each validator set its own fee recipient, --reward-address
+sends all the fees to the given address, and --disable burns them. The signing key
+must be on the allow list of the reward manager.`,
+		SilenceUsage: true,
+		RunE:         setRewards,
+		Args:         cobra.ExactArgs(1),
+	}
+	addPrecompileFlags(cmd)
+	cmd.Flags().BoolVar(&allowFeeRecipients, "allow-fee-recipients", false, "let validators set their fee recipient")
+	cmd.Flags().StringVar(&rewardAddressStr, "reward-address", "", "send all the fees to this address")
+	cmd.Flags().BoolVar(&disableRewards, "disable", false, "burn all the fees")
+	return cmd
+}
+
+func getRewards(_ *cobra.Command, args []string) error {
+	_, rpcURL, err := getPrecompileRPCURL(args[0])
+	if err != nil {
+		return err
+	}
+	rewardConfig, err := evm.GetRewardConfig(rpcURL)
+	if err != nil {
+		if errors.Is(err, evm.ErrPrecompileNotActivated) {
+			return errors.New("the reward manager precompile is not activated on this chain")
+		}
+		return err
+	}
+	printRewardConfig(rewardConfig)
+
+	chainConfig, err := evm.GetChainConfig(rpcURL)
+	if err != nil {
+		return err
+	}
+	addresses := getRewardManagerAddresses(chainConfig)
+	for _, addressStr := range rewardRoleAddresses {
+		if !common.IsHexAddress(addressStr) {
+			return fmt.Errorf("invalid address %q", addressStr)
+		}
+		addresses = append(addresses, common.HexToAddress(addressStr))
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Address", "Role"})
+	table.SetRowLine(true)
+	seen := map[common.Address]bool{}
+	for _, address := range addresses {
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+		role, err := evm.GetAllowListRole(rpcURL, rewardmanager.ContractAddress, address)
+		if err != nil {
+			return err
+		}
+		table.Append([]string{address.Hex(), role})
+	}
+	table.Render()
+	return nil
+}
+
+func setRewards(_ *cobra.Command, args []string) error {
+	if !flags.EnsureMutuallyExclusive([]bool{allowFeeRecipients, rewardAddressStr != "", disableRewards}) {
+		return errors.New("--allow-fee-recipients, --reward-address and --disable are mutually exclusive")
+	}
+	var (
+		mode          string
+		rewardAddress common.Address
+		err           error
+	)
+	switch {
+	case allowFeeRecipients:
+		mode = evm.FeeRecipientsRewardMode
+	case rewardAddressStr != "":
+		mode = evm.RewardAddressRewardMode
+	case disableRewards:
+		mode = evm.DisabledRewardMode
+	default:
+		mode, err = app.Prompt.CaptureList(
+			"Choose how to reward the block fees",
+			[]string{evm.FeeRecipientsRewardMode, evm.RewardAddressRewardMode, evm.DisabledRewardMode},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if mode == evm.RewardAddressRewardMode {
+		rewardAddress, err = parseAddress(rewardAddressStr, "Reward address")
+		if err != nil {
+			return err
+		}
+	}
+	input, err := evm.PackSetRewardConfig(mode, rewardAddress)
+	if err != nil {
+		return err
+	}
+
+	network, rpcURL, err := getPrecompileRPCURL(args[0])
+	if err != nil {
+		return err
+	}
+	current, err := evm.GetRewardConfig(rpcURL)
+	if err != nil {
+		if errors.Is(err, evm.ErrPrecompileNotActivated) {
+			return errors.New("the reward manager precompile is not activated on this chain")
+		}
+		return err
+	}
+	if current.Mode() == mode && (mode != evm.RewardAddressRewardMode || current.RewardAddress == rewardAddress) {
+		ux.Logger.PrintToUser("The reward mode is already set")
+		printRewardConfig(current)
+		return nil
+	}
+	privateKey, err := getPrecompileKey(network)
+	if err != nil {
+		return err
+	}
+	if err := checkPrecompileEnabled(rpcURL, rewardManagerPrecompile, privateKey); err != nil {
+		return err
+	}
+	receipt, err := evm.SendTx(rpcURL, privateKey, rewardmanager.ContractAddress, nil, input)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Reward mode set to %s in tx %s (block %s)", mode, receipt.TxHash, receipt.BlockNumber)
+	return nil
+}
+
+func printRewardConfig(rewardConfig evm.RewardConfig) {
+	switch rewardConfig.Mode() {
+	case evm.FeeRecipientsRewardMode:
+		ux.Logger.PrintToUser("Reward mode: fee recipients. Validators get the fees at the fee recipient they set")
+	case evm.DisabledRewardMode:
+		ux.Logger.PrintToUser("Reward mode: disabled. Fees are burned")
+	default:
+		ux.Logger.PrintToUser("Reward mode: reward address. Fees are sent to %s", rewardConfig.RewardAddress.Hex())
+	}
+}
+
+// getRewardManagerAddresses returns the admin and enabled addresses of the
+// reward manager set at genesis or by the upgrades of [chainConfig]
+func getRewardManagerAddresses(chainConfig params.ChainConfigWithUpgradesJSON) []common.Address {
+	addresses := []common.Address{}
+	add := func(config interface{}) {
+		if cfg, ok := config.(*rewardmanager.Config); ok {
+			addresses = append(addresses, cfg.AdminAddresses...)
+			addresses = append(addresses, cfg.EnabledAddresses...)
+		}
+	}
+	if config, ok := chainConfig.GenesisPrecompiles[rewardmanager.ConfigKey]; ok {
+		add(config)
+	}
+	for _, upgrade := range chainConfig.UpgradeConfig.PrecompileUpgrades {
+		add(upgrade.Config)
+	}
+	return addresses
+}
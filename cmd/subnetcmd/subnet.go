@@ -70,5 +70,23 @@ manage your Subnet configurations and live deployments.`,
 	cmd.AddCommand(newValidatorsCmd())
 	// subnet addPermissionlessDelegator
 	cmd.AddCommand(newAddPermissionlessDelegatorCmd())
+	// subnet addChain
+	cmd.AddCommand(newAddChainCmd())
+	// subnet clone
+	cmd.AddCommand(newCloneCmd())
+	// subnet rename
+	cmd.AddCommand(newRenameCmd())
+	// subnet health
+	cmd.AddCommand(newHealthCmd())
+	// subnet precompile
+	cmd.AddCommand(newPrecompileCmd())
+	// subnet mint
+	cmd.AddCommand(newMintCmd())
+	// subnet fees
+	cmd.AddCommand(newFeesCmd())
+	// subnet rewards
+	cmd.AddCommand(newRewardsCmd())
+	// subnet lint
+	cmd.AddCommand(newLintCmd())
 	return cmd
 }
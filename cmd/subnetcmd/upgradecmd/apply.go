@@ -40,7 +40,7 @@ var (
 	errInvalidPrecompiles         = errors.New("invalid precompiles")
 	errNoBlockTimestamp           = errors.New("no blockTimestamp value set")
 	errBlockTimestampInvalid      = errors.New("blockTimestamp is invalid")
-	errNoPrecompiles              = errors.New("no precompiles present")
+	errNoPrecompiles              = errors.New("no precompile or state upgrades present")
 	errNoUpcomingUpgrades         = errors.New("no valid upcoming activation timestamp found")
 	errNewUpgradesNotContainsLock = errors.New("the new upgrade file does not contain the content of the lock file")
 
@@ -50,7 +50,8 @@ var (
 	avalanchegoChainConfigFlag       = "avalanchego-chain-config-dir"
 	avalanchegoChainConfigDir        string
 
-	print bool
+	print       bool
+	clusterName string
 )
 
 // avalanche subnet upgrade apply
@@ -68,6 +69,10 @@ to upgrade your node manually.
 
 After you update your validator's configuration, you need to restart your validator manually.
 If you provide the --avalanchego-chain-config-dir flag, this command attempts to write the upgrade file at that path.
+
+With --cluster, the upgrade file of the Fuji deployment is instead installed on the nodes of a
+cluster created with avalanche node create. The nodes are restarted one at a time, and each one
+must come back healthy and report the new upgrade config before the next one is restarted.
 Refer to https://docs.avax.network/nodes/maintain/chain-config-flags#subnet-chain-configs for related documentation.`,
 		RunE: applyCmd,
 		Args: cobra.ExactArgs(1),
@@ -81,6 +86,7 @@ Refer to https://docs.avax.network/nodes/maintain/chain-config-flags#subnet-chai
 	cmd.Flags().BoolVar(&print, "print", false, "if true, print the manual config without prompting (for public networks only)")
 	cmd.Flags().BoolVar(&force, "force", false, "If true, don't prompt for confirmation of timestamps in the past")
 	cmd.Flags().StringVar(&avalanchegoChainConfigDir, avalanchegoChainConfigFlag, os.ExpandEnv(avalanchegoChainConfigDirDefault), "avalanchego's chain config file directory")
+	cmd.Flags().StringVar(&clusterName, "cluster", "", "apply upgrade to the nodes of the given `cluster`, which validate the fuji deployment")
 
 	return cmd
 }
@@ -97,6 +103,10 @@ func applyCmd(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("unable to load sidecar: %w", err)
 	}
 
+	if clusterName != "" {
+		return applyClusterUpgrade(subnetName, clusterName, &sc)
+	}
+
 	networkToUpgrade, err := selectNetworkToUpgrade(sc, []string{})
 	if err != nil {
 		return err
@@ -132,7 +142,7 @@ func applyLocalNetworkUpgrade(subnetName, networkKey string, sc *models.Sidecar)
 	if print {
 		ux.Logger.PrintToUser("The --print flag is ignored on local networks. Continuing.")
 	}
-	precmpUpgrades, strNetUpgrades, err := validateUpgrade(subnetName, networkKey, sc, force)
+	upgradeConfig, strNetUpgrades, err := validateUpgrade(subnetName, networkKey, sc, force)
 	if err != nil {
 		return err
 	}
@@ -188,6 +198,10 @@ func applyLocalNetworkUpgrade(subnetName, networkKey string, sc *models.Sidecar)
 	netUpgradeConfs := map[string]string{
 		blockchainID.String(): strNetUpgrades,
 	}
+	// keep the already applied upgrades of the other blockchains of the subnet
+	if err := addSiblingChainsUpgrades(netUpgradeConfs, subnetName, networkKey, sc); err != nil {
+		return err
+	}
 	// restart the network setting the upgrade bytes file
 	opts := ANRclient.WithUpgradeConfigs(netUpgradeConfs)
 	_, err = cli.LoadSnapshot(ctx, snapName, opts)
@@ -204,7 +218,7 @@ func applyLocalNetworkUpgrade(subnetName, networkKey string, sc *models.Sidecar)
 	if subnet.HasEndpoints(clusterInfo) {
 		ux.Logger.PrintToUser("Network restarted and ready to use. Upgrade bytes have been applied to running nodes at these endpoints.")
 
-		nextUpgrade, err := getEarliestUpcomingTimestamp(precmpUpgrades)
+		nextUpgrade, err := getEarliestUpcomingTimestamp(upgradeConfig)
 		// this should not happen anymore at this point...
 		if err != nil {
 			app.Log.Warn("looks like the upgrade went well, but we failed getting the timestamp of the next upcoming upgrade: %w")
@@ -212,7 +226,7 @@ func applyLocalNetworkUpgrade(subnetName, networkKey string, sc *models.Sidecar)
 		ux.Logger.PrintToUser("The next upgrade will go into effect %s", time.Unix(nextUpgrade, 0).Local().Format(constants.TimeParseLayout))
 		ux.PrintTableEndpoints(clusterInfo)
 
-		return writeLockFile(precmpUpgrades, subnetName)
+		return writeLockFile(upgradeConfig, subnetName)
 	}
 
 	return errors.New("unexpected network size of zero nodes")
@@ -300,14 +314,14 @@ func applyPublicNetworkUpgrade(subnetName, networkKey string, sc *models.Sidecar
 	return nil
 }
 
-func validateUpgrade(subnetName, networkKey string, sc *models.Sidecar, skipPrompting bool) ([]params.PrecompileUpgrade, string, error) {
+func validateUpgrade(subnetName, networkKey string, sc *models.Sidecar, skipPrompting bool) (params.UpgradeConfig, string, error) {
 	// if there's no entry in the Sidecar, we assume there hasn't been a deploy yet
 	if sc.Networks[networkKey] == (models.NetworkData{}) {
-		return nil, "", subnetNotYetDeployed()
+		return params.UpgradeConfig{}, "", subnetNotYetDeployed()
 	}
 	chainID := sc.Networks[networkKey].BlockchainID
 	if chainID == ids.Empty {
-		return nil, "", errors.New(ErrSubnetNotDeployedOutput)
+		return params.UpgradeConfig{}, "", errors.New(ErrSubnetNotDeployedOutput)
 	}
 	// let's check update bytes actually exist
 	netUpgradeBytes, err := app.ReadUpgradeFile(subnetName)
@@ -317,7 +331,7 @@ func validateUpgrade(subnetName, networkKey string, sc *models.Sidecar, skipProm
 			ux.Logger.PrintToUser("You may need to first create it with the `avalanche subnet upgrade generate` command or import it")
 			ux.Logger.PrintToUser("Aborting this command. No changes applied")
 		}
-		return nil, "", err
+		return params.UpgradeConfig{}, "", err
 	}
 
 	// read the lock file right away
@@ -325,29 +339,29 @@ func validateUpgrade(subnetName, networkKey string, sc *models.Sidecar, skipProm
 	if err != nil {
 		// if the file doesn't exist, that's ok
 		if !os.IsNotExist(err) {
-			return nil, "", err
+			return params.UpgradeConfig{}, "", err
 		}
 	}
 
 	// validate the upgrade bytes files
-	upgrds, err := validateUpgradeBytes(netUpgradeBytes, lockUpgradeBytes, skipPrompting)
+	upgradeConfig, err := validateUpgradeBytes(netUpgradeBytes, lockUpgradeBytes, skipPrompting)
 	if err != nil {
-		return nil, "", err
+		return params.UpgradeConfig{}, "", err
 	}
 
 	// checks that adminAddress in precompile upgrade for TxAllowList has enough token balance
-	for _, precmpUpgrade := range upgrds {
+	for _, precmpUpgrade := range upgradeConfig.PrecompileUpgrades {
 		allowListCfg, ok := precmpUpgrade.Config.(*txallowlist.Config)
 		if !ok {
 			continue
 		}
 		if allowListCfg != nil {
 			if err := ensureAdminsHaveBalance(allowListCfg.AdminAddresses, subnetName); err != nil {
-				return nil, "", err
+				return params.UpgradeConfig{}, "", err
 			}
 		}
 	}
-	return upgrds, string(netUpgradeBytes), nil
+	return upgradeConfig, string(netUpgradeBytes), nil
 }
 
 func subnetNotYetDeployed() error {
@@ -356,14 +370,11 @@ func subnetNotYetDeployed() error {
 	return errSubnetNotYetDeployed
 }
 
-func writeLockFile(precmpUpgrades []params.PrecompileUpgrade, subnetName string) error {
+func writeLockFile(upgradeConfig params.UpgradeConfig, subnetName string) error {
 	// it seems all went well this far, now we try to write/update the lock file
 	// if this fails, we probably don't want to cause an error to the user?
 	// so we are silently failing, just write a log entry
-	wrapper := params.UpgradeConfig{
-		PrecompileUpgrades: precmpUpgrades,
-	}
-	jsonBytes, err := json.Marshal(wrapper)
+	jsonBytes, err := json.Marshal(&upgradeConfig)
 	if err != nil {
 		app.Log.Debug("failed to marshaling upgrades lock file content", zap.Error(err))
 	}
@@ -374,34 +385,29 @@ func writeLockFile(precmpUpgrades []params.PrecompileUpgrade, subnetName string)
 	return nil
 }
 
-func validateUpgradeBytes(file, lockFile []byte, skipPrompting bool) ([]params.PrecompileUpgrade, error) {
-	upgrades, err := getAllUpgrades(file)
+func validateUpgradeBytes(file, lockFile []byte, skipPrompting bool) (params.UpgradeConfig, error) {
+	upgradeConfig, err := getUpgradeConfig(file)
 	if err != nil {
-		return nil, err
+		return params.UpgradeConfig{}, err
+	}
+	if err := validateStateUpgrades(file, upgradeConfig.StateUpgrades); err != nil {
+		return params.UpgradeConfig{}, err
 	}
 
 	if len(lockFile) > 0 {
-		lockUpgrades, err := getAllUpgrades(lockFile)
+		lockConfig, err := getUpgradeConfig(lockFile)
 		if err != nil {
-			return nil, err
-		}
-		match := 0
-		for _, lu := range lockUpgrades {
-			for _, u := range upgrades {
-				if reflect.DeepEqual(u, lu) {
-					match++
-					break
-				}
-			}
+			return params.UpgradeConfig{}, err
 		}
-		if match != len(lockUpgrades) {
-			return nil, errNewUpgradesNotContainsLock
+		if !containsAllUpgrades(upgradeConfig.PrecompileUpgrades, lockConfig.PrecompileUpgrades) ||
+			!containsAllUpgrades(upgradeConfig.StateUpgrades, lockConfig.StateUpgrades) {
+			return params.UpgradeConfig{}, errNewUpgradesNotContainsLock
 		}
 	}
 
-	allTimestamps, err := getAllTimestamps(upgrades)
+	allTimestamps, err := getAllTimestamps(upgradeConfig)
 	if err != nil {
-		return nil, err
+		return params.UpgradeConfig{}, err
 	}
 
 	if !skipPrompting {
@@ -416,32 +422,56 @@ func validateUpgradeBytes(file, lockFile []byte, skipPrompting bool) ([]params.P
 					"The config MUST be removed. Use caution before proceeding")
 				yes, err := app.Prompt.CaptureYesNo("Do you want to continue (use --force to skip prompting)?")
 				if err != nil {
-					return nil, err
+					return params.UpgradeConfig{}, err
 				}
 				if !yes {
 					ux.Logger.PrintToUser("No selected.")
-					return nil, errUserAborted
+					return params.UpgradeConfig{}, errUserAborted
 				}
 			}
 		}
 	}
 
-	return upgrades, nil
+	return upgradeConfig, nil
 }
 
-func getAllTimestamps(upgrades []params.PrecompileUpgrade) ([]int64, error) {
+// containsAllUpgrades tells if all of [lockUpgrades] are in [upgrades]
+func containsAllUpgrades[T any](upgrades, lockUpgrades []T) bool {
+	for _, lu := range lockUpgrades {
+		found := false
+		for _, u := range upgrades {
+			if reflect.DeepEqual(u, lu) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func getAllTimestamps(upgradeConfig params.UpgradeConfig) ([]int64, error) {
 	allTimestamps := []int64{}
 
-	if len(upgrades) == 0 {
+	if len(upgradeConfig.PrecompileUpgrades) == 0 && len(upgradeConfig.StateUpgrades) == 0 {
 		return nil, errNoBlockTimestamp
 	}
-	for _, upgrade := range upgrades {
+	for _, upgrade := range upgradeConfig.PrecompileUpgrades {
 		ts, err := validateTimestamp(upgrade.Timestamp())
 		if err != nil {
 			return nil, err
 		}
 		allTimestamps = append(allTimestamps, ts)
 	}
+	for _, upgrade := range upgradeConfig.StateUpgrades {
+		ts, err := validateTimestamp(upgrade.BlockTimestamp)
+		if err != nil {
+			return nil, err
+		}
+		allTimestamps = append(allTimestamps, ts)
+	}
 	if len(allTimestamps) == 0 {
 		return nil, errNoBlockTimestamp
 	}
@@ -459,8 +489,8 @@ func validateTimestamp(ts *uint64) (int64, error) {
 	return int64(val), nil
 }
 
-func getEarliestUpcomingTimestamp(upgrades []params.PrecompileUpgrade) (int64, error) {
-	allTimestamps, err := getAllTimestamps(upgrades)
+func getEarliestUpcomingTimestamp(upgradeConfig params.UpgradeConfig) (int64, error) {
+	allTimestamps, err := getAllTimestamps(upgradeConfig)
 	if err != nil {
 		return 0, err
 	}
@@ -485,17 +515,53 @@ func getEarliestUpcomingTimestamp(upgrades []params.PrecompileUpgrade) (int64, e
 	return earliest, nil
 }
 
-func getAllUpgrades(file []byte) ([]params.PrecompileUpgrade, error) {
-	var precompiles params.UpgradeConfig
+// getUpgradeConfig parses the precompile and state upgrades of an upgrade file
+func getUpgradeConfig(file []byte) (params.UpgradeConfig, error) {
+	var upgradeConfig params.UpgradeConfig
 
-	if err := json.Unmarshal(file, &precompiles); err != nil {
+	if err := json.Unmarshal(file, &upgradeConfig); err != nil {
 		cause := fmt.Errorf("failed parsing JSON: %w", err)
-		return nil, fmt.Errorf(cause.Error()+" - %w ", errInvalidPrecompiles)
+		return params.UpgradeConfig{}, fmt.Errorf(cause.Error()+" - %w ", errInvalidPrecompiles)
 	}
 
-	if len(precompiles.PrecompileUpgrades) == 0 {
-		return nil, errNoPrecompiles
+	if len(upgradeConfig.PrecompileUpgrades) == 0 && len(upgradeConfig.StateUpgrades) == 0 {
+		return params.UpgradeConfig{}, errNoPrecompiles
 	}
 
-	return precompiles.PrecompileUpgrades, nil
+	return upgradeConfig, nil
+}
+
+// addSiblingChainsUpgrades adds to [netUpgradeConfs] the applied upgrade bytes of the
+// other locally deployed blockchains that belong to the same subnet as [subnetName]
+func addSiblingChainsUpgrades(netUpgradeConfs map[string]string, subnetName, networkKey string, sc *models.Sidecar) error {
+	parent := sc.Subnet
+	if parent == "" {
+		parent = subnetName
+	}
+	chains, err := app.GetChainsInSubnet(parent)
+	if err != nil {
+		return err
+	}
+	for _, chain := range chains {
+		if chain == subnetName {
+			continue
+		}
+		chainSC, err := app.LoadSidecar(chain)
+		if err != nil {
+			return err
+		}
+		chainID := chainSC.Networks[networkKey].BlockchainID
+		if chainID == ids.Empty {
+			continue
+		}
+		lockUpgradeBytes, err := app.ReadLockUpgradeFile(chain)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		netUpgradeConfs[chainID.String()] = string(lockUpgradeBytes)
+	}
+	return nil
 }
@@ -0,0 +1,162 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package upgradecmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/ansible"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/subnet-evm/params"
+	"go.uber.org/zap"
+)
+
+// applyClusterUpgrade installs the upgrade file of [subnetName] on the nodes of
+// [clusterName], which validate its Fuji deployment. The nodes are restarted one
+// at a time, and each one must come back healthy and report the upgrades of the
+// file before the next one is restarted
+func applyClusterUpgrade(subnetName, clusterName string, sc *models.Sidecar) error {
+	if err := checkClusterExists(clusterName); err != nil {
+		return err
+	}
+	if err := ansible.CheckIsInstalled(); err != nil {
+		return err
+	}
+	networkKey := models.Fuji.String()
+	upgradeConfig, _, err := validateUpgrade(subnetName, networkKey, sc, force)
+	if err != nil {
+		return err
+	}
+	blockchainID := sc.Networks[networkKey].BlockchainID
+
+	// the ansible dir is rewritten in case the playbooks changed
+	if err := app.SetupAnsibleEnv(); err != nil {
+		return err
+	}
+	if err := ansible.Setup(app.GetAnsibleDir()); err != nil {
+		return err
+	}
+	// status files left by a previous run must not be taken for the nodes' ones
+	if err := app.RemoveAnsibleStatusDir(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(app.GetAnsibleStatusDir(), constants.DefaultPerms755); err != nil {
+		return err
+	}
+	defer func() {
+		if err := app.RemoveAnsibleStatusDir(); err != nil {
+			app.Log.Debug("failed to remove the ansible status dir", zap.Error(err))
+		}
+	}()
+	ux.Logger.PrintToUser("Installing the upgrade file on the nodes of cluster %s, restarting them one at a time ...", clusterName)
+	if err := ansible.RunAnsiblePlaybookUpgradeSubnet(
+		app.GetAnsibleDir(),
+		app.GetAnsibleInventoryPath(clusterName),
+		app.GetUpgradeBytesFilePath(subnetName),
+		blockchainID.String(),
+		constants.CloudNodeChainConfigDir,
+		app.GetAnsibleStatusDir(),
+	); err != nil {
+		return fmt.Errorf("failed to upgrade the nodes of cluster %s: %w", clusterName, err)
+	}
+
+	files, err := os.ReadDir(app.GetAnsibleStatusDir())
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no node of cluster %s reported its chain config", clusterName)
+	}
+	mismatches := []string{}
+	for _, file := range files {
+		host := strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))
+		nodeUpgradeConfig, err := parseNodeUpgradeConfig(filepath.Join(app.GetAnsibleStatusDir(), file.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to get the chain config of %s: %w", host, err)
+		}
+		same, err := sameUpgradeConfig(upgradeConfig, nodeUpgradeConfig)
+		if err != nil {
+			return err
+		}
+		if !same {
+			ux.Logger.PrintToUser("Node %s is healthy, but reports a different upgrade config", host)
+			mismatches = append(mismatches, host)
+			continue
+		}
+		ux.Logger.PrintToUser("Node %s is healthy and reports the new upgrade config", host)
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("the upgrade config of nodes %s does not match the upgrade file", strings.Join(mismatches, ", "))
+	}
+	ux.Logger.PrintToUser("Successfully upgraded the nodes of cluster %s", clusterName)
+	return writeLockFile(upgradeConfig, subnetName)
+}
+
+func checkClusterExists(clusterName string) error {
+	if !app.ClusterConfigExists() {
+		return fmt.Errorf("cluster %q does not exist", clusterName)
+	}
+	clusterConfig, err := app.LoadClusterConfig()
+	if err != nil {
+		return err
+	}
+	if len(clusterConfig.Clusters[clusterName]) == 0 {
+		return fmt.Errorf("cluster %q does not exist or has no nodes", clusterName)
+	}
+	return nil
+}
+
+// parseNodeUpgradeConfig reads the upgrade config from the eth_getChainConfig
+// response saved at [filePath]
+func parseNodeUpgradeConfig(filePath string) (params.UpgradeConfig, error) {
+	chainConfig, err := parseNodeChainConfig(filePath)
+	if err != nil {
+		return params.UpgradeConfig{}, err
+	}
+	return chainConfig.UpgradeConfig, nil
+}
+
+// parseNodeChainConfig reads the eth_getChainConfig response saved at [filePath]
+func parseNodeChainConfig(filePath string) (*params.ChainConfigWithUpgradesJSON, error) {
+	byteValue, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Result *params.ChainConfigWithUpgradesJSON `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(byteValue, &response); err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		return nil, errors.New(response.Error.Message)
+	}
+	if response.Result == nil {
+		return nil, errors.New("empty chain config")
+	}
+	return response.Result, nil
+}
+
+// sameUpgradeConfig tells if [a] and [b] have the same upgrades, once encoded
+func sameUpgradeConfig(a, b params.UpgradeConfig) (bool, error) {
+	aBytes, err := json.Marshal(&a)
+	if err != nil {
+		return false, err
+	}
+	bBytes, err := json.Marshal(&b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(aBytes, bBytes), nil
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package upgradecmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNodeUpgradeConfig(t *testing.T) {
+	require := require.New(t)
+	upgradesFile := []byte(`{"precompileUpgrades":[{"feeManagerConfig":{"adminAddresses":["0xb794f5ea0ba39494ce839613fffba74279579268"],"blockTimestamp":1674496268}}]}`)
+	upgradeConfig, err := getUpgradeConfig(upgradesFile)
+	require.NoError(err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aws-node.json")
+	response := `{"jsonrpc":"2.0","id":1,"result":{"chainId":43214,"feeConfig":{"gasLimit":8000000},"upgrades":` + string(upgradesFile) + `}}`
+	require.NoError(os.WriteFile(path, []byte(response), 0o600))
+	nodeUpgradeConfig, err := parseNodeUpgradeConfig(path)
+	require.NoError(err)
+	same, err := sameUpgradeConfig(upgradeConfig, nodeUpgradeConfig)
+	require.NoError(err)
+	require.True(same)
+
+	otherConfig, err := getUpgradeConfig([]byte(`{"precompileUpgrades":[{"txAllowListConfig":{"adminAddresses":["0xb794f5ea0ba39494ce839613fffba74279579268"],"blockTimestamp":1674496268}}]}`))
+	require.NoError(err)
+	same, err = sameUpgradeConfig(otherConfig, nodeUpgradeConfig)
+	require.NoError(err)
+	require.False(same)
+
+	require.NoError(os.WriteFile(path, []byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"the method eth_getChainConfig does not exist"}}`), 0o600))
+	_, err = parseNodeUpgradeConfig(path)
+	require.ErrorContains(err, "does not exist")
+}
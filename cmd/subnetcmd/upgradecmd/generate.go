@@ -44,7 +44,10 @@ const (
 	adminLabel   = "admin"
 )
 
-var subnetName string
+var (
+	subnetName      string
+	upgradeSpecFile string
+)
 
 // avalanche subnet upgrade generate
 func newUpgradeGenerateCmd() *cobra.Command {
@@ -52,10 +55,34 @@ func newUpgradeGenerateCmd() *cobra.Command {
 		Use:   "generate [subnetName]",
 		Short: "Generate the configuration file to upgrade subnet nodes",
 		Long: `The subnet upgrade generate command builds a new upgrade.json file to customize your Subnet. It
-guides the user through the process using an interactive wizard.`,
+guides the user through the process using an interactive wizard.
+
+Besides enabling and disabling precompiles, the wizard can author state upgrades,
+which change the balance, code or storage of accounts at a given timestamp. Their
+accounts must be entered as checksummed addresses, and the current code hash of each
+account is shown from the chain RPC selected with --local or --rpc, or at the prompt.
+
+With --from, the upgrades are instead read from a YAML spec file, such as:
+
+  upgrades:
+    - precompile: feeManagerConfig
+      timestamp: "+48h"
+      adminAddresses: ["0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"]
+    - precompile: txAllowListConfig
+      disable: true
+      timestamp: "2030-01-02 15:04:05"
+
+Timestamps are UTC dates, unix timestamps, or offsets from now. Each upgrade can also
+set initialFeeConfig, initialMint or initialRewardConfig, as in upgrade.json. The
+upgrades already applied, recorded in the lock file, are kept, the new ones must be
+in the future, and the changes to the current upgrade file are printed. Removing
+upgrades of the current file, not locked yet, has to be confirmed, or forced with --force.`,
 		RunE: upgradeGenerateCmd,
 		Args: cobra.ExactArgs(1),
 	}
+	cmd.Flags().StringVar(&upgradeSpecFile, "from", "", "generate the upgrades from this YAML spec file, without prompting")
+	addStateUpgradeRPCFlags(cmd)
+	cmd.Flags().BoolVar(&force, "force", false, "with --from, remove the upgrades of the current file missing from the spec without prompting")
 	return cmd
 }
 
@@ -65,6 +92,9 @@ func upgradeGenerateCmd(_ *cobra.Command, args []string) error {
 		ux.Logger.PrintToUser("The provided subnet name %q does not exist", subnetName)
 		return nil
 	}
+	if upgradeSpecFile != "" {
+		return upgradeGenerateFromSpec(subnetName, upgradeSpecFile)
+	}
 	// print some warning/info message
 	ux.Logger.PrintToUser(logging.Bold.Wrap(logging.Yellow.Wrap(
 		"Performing a network upgrade requires coordinating the upgrade network-wide.")))
@@ -96,6 +126,7 @@ func upgradeGenerateCmd(_ *cobra.Command, args []string) error {
 		vm.NativeMint,
 		vm.TxAllowList,
 		vm.RewardManager,
+		stateUpgradeOption,
 	}
 
 	fmt.Println()
@@ -110,14 +141,21 @@ func upgradeGenerateCmd(_ *cobra.Command, args []string) error {
 	}
 
 	for {
-		precomp, err := app.Prompt.CaptureList("Select the precompile to configure", allPreComps)
+		precomp, err := app.Prompt.CaptureList("Select the precompile, or state upgrade, to configure", allPreComps)
 		if err != nil {
 			return err
 		}
 
-		ux.Logger.PrintToUser(fmt.Sprintf("Set parameters for the %q precompile", precomp))
-		if err := promptParams(precomp, &precompiles.PrecompileUpgrades); err != nil {
-			return err
+		if precomp == stateUpgradeOption {
+			ux.Logger.PrintToUser("Set the account changes of the state upgrade")
+			if err := promptStateUpgradeParams(subnetName, &precompiles.StateUpgrades); err != nil {
+				return err
+			}
+		} else {
+			ux.Logger.PrintToUser(fmt.Sprintf("Set parameters for the %q precompile", precomp))
+			if err := promptParams(precomp, &precompiles.PrecompileUpgrades); err != nil {
+				return err
+			}
 		}
 
 		if len(allPreComps) > 1 {
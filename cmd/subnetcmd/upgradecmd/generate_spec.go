@@ -0,0 +1,326 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package upgradecmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/deployerallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"gopkg.in/yaml.v3"
+)
+
+// upgradeSpec is the declarative description of the upgrades to generate
+type upgradeSpec struct {
+	Upgrades []precompileSpec `json:"upgrades"`
+}
+
+// precompileSpec describes the enabling, or disabling, of a precompile.
+// Timestamp is either a 'YYYY-MM-DD HH:MM:SS' UTC date, a unix timestamp,
+// or an offset from now such as "+48h"
+type precompileSpec struct {
+	Precompile          string                             `json:"precompile"`
+	Disable             bool                               `json:"disable"`
+	Timestamp           interface{}                        `json:"timestamp"`
+	AdminAddresses      []common.Address                   `json:"adminAddresses"`
+	EnabledAddresses    []common.Address                   `json:"enabledAddresses"`
+	InitialFeeConfig    *commontype.FeeConfig              `json:"initialFeeConfig"`
+	InitialMint         map[common.Address]string          `json:"initialMint"`
+	InitialRewardConfig *rewardmanager.InitialRewardConfig `json:"initialRewardConfig"`
+}
+
+// loadUpgradeSpec reads a YAML, or JSON, upgrade spec file
+func loadUpgradeSpec(path string) (upgradeSpec, error) {
+	specBytes, err := os.ReadFile(path)
+	if err != nil {
+		return upgradeSpec{}, err
+	}
+	// go through JSON so that the subnet-evm types decode as in upgrade.json
+	var tree interface{}
+	if err := yaml.Unmarshal(specBytes, &tree); err != nil {
+		return upgradeSpec{}, fmt.Errorf("invalid upgrade spec %s: %w", path, err)
+	}
+	jsonBytes, err := json.Marshal(tree)
+	if err != nil {
+		return upgradeSpec{}, fmt.Errorf("invalid upgrade spec %s: %w", path, err)
+	}
+	var spec upgradeSpec
+	if err := json.Unmarshal(jsonBytes, &spec); err != nil {
+		return upgradeSpec{}, fmt.Errorf("invalid upgrade spec %s: %w", path, err)
+	}
+	if len(spec.Upgrades) == 0 {
+		return upgradeSpec{}, fmt.Errorf("upgrade spec %s has no upgrades", path)
+	}
+	return spec, nil
+}
+
+// parseSpecTimestamp converts the timestamp of a spec into a time, relative to [now]
+func parseSpecTimestamp(timestamp interface{}, now time.Time) (time.Time, error) {
+	switch ts := timestamp.(type) {
+	case nil:
+		return time.Time{}, errors.New("missing timestamp")
+	case float64:
+		return time.Unix(int64(ts), 0), nil
+	case string:
+		if strings.HasPrefix(ts, "+") {
+			offset, err := time.ParseDuration(strings.TrimPrefix(ts, "+"))
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid timestamp offset %q", ts)
+			}
+			return now.Add(offset), nil
+		}
+		if t, err := time.Parse(constants.TimeParseLayout, ts); err == nil {
+			return t, nil
+		}
+		// YAML dates are decoded as times, which are then encoded as RFC3339
+		if t, err := time.Parse(time.RFC3339, ts); err == nil {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("invalid timestamp %q, expected 'YYYY-MM-DD HH:MM:SS', a unix timestamp or an offset like +48h", ts)
+	default:
+		return time.Time{}, fmt.Errorf("invalid timestamp %v", timestamp)
+	}
+}
+
+// buildPrecompileUpgrades converts the spec into precompile upgrades, checking
+// that all of them happen after [now]
+func buildPrecompileUpgrades(spec upgradeSpec, now time.Time) ([]params.PrecompileUpgrade, error) {
+	upgrades := []params.PrecompileUpgrade{}
+	for i, precompile := range spec.Upgrades {
+		date, err := parseSpecTimestamp(precompile.Timestamp, now)
+		if err != nil {
+			return nil, fmt.Errorf("upgrade %d (%s): %w", i+1, precompile.Precompile, err)
+		}
+		if !date.After(now) {
+			return nil, fmt.Errorf("upgrade %d (%s): timestamp %s is not in the future",
+				i+1, precompile.Precompile, date.UTC().Format(constants.TimeParseLayout))
+		}
+		config, err := buildPrecompileConfig(precompile, utils.NewUint64(uint64(date.Unix())))
+		if err != nil {
+			return nil, fmt.Errorf("upgrade %d (%s): %w", i+1, precompile.Precompile, err)
+		}
+		if err := config.Verify(); err != nil {
+			return nil, fmt.Errorf("upgrade %d (%s): %w", i+1, precompile.Precompile, err)
+		}
+		upgrades = append(upgrades, params.PrecompileUpgrade{Config: config})
+	}
+	return upgrades, nil
+}
+
+func buildPrecompileConfig(precompile precompileSpec, timestamp *uint64) (precompileconfig.Config, error) {
+	hasParams := len(precompile.AdminAddresses) > 0 || len(precompile.EnabledAddresses) > 0 ||
+		precompile.InitialFeeConfig != nil || len(precompile.InitialMint) > 0 || precompile.InitialRewardConfig != nil
+	if precompile.Disable && hasParams {
+		return nil, errors.New("a disable upgrade can't have addresses or initial configs")
+	}
+	if !precompile.Disable && len(precompile.AdminAddresses) == 0 && len(precompile.EnabledAddresses) == 0 {
+		return nil, fmt.Errorf("at least one of %s or %s is needed", adminAddressesKey, enabledAddressesKey)
+	}
+	if precompile.InitialFeeConfig != nil && precompile.Precompile != feemanager.ConfigKey {
+		return nil, fmt.Errorf("%s is only valid for %s", feeConfigKey, feemanager.ConfigKey)
+	}
+	if len(precompile.InitialMint) > 0 && precompile.Precompile != nativeminter.ConfigKey {
+		return nil, fmt.Errorf("%s is only valid for %s", initialMintKey, nativeminter.ConfigKey)
+	}
+	if precompile.InitialRewardConfig != nil && precompile.Precompile != rewardmanager.ConfigKey {
+		return nil, fmt.Errorf("initialRewardConfig is only valid for %s", rewardmanager.ConfigKey)
+	}
+	admins, enableds := precompile.AdminAddresses, precompile.EnabledAddresses
+	switch precompile.Precompile {
+	case deployerallowlist.ConfigKey:
+		if precompile.Disable {
+			return deployerallowlist.NewDisableConfig(timestamp), nil
+		}
+		return deployerallowlist.NewConfig(timestamp, admins, enableds), nil
+	case txallowlist.ConfigKey:
+		if precompile.Disable {
+			return txallowlist.NewDisableConfig(timestamp), nil
+		}
+		return txallowlist.NewConfig(timestamp, admins, enableds), nil
+	case feemanager.ConfigKey:
+		if precompile.Disable {
+			return feemanager.NewDisableConfig(timestamp), nil
+		}
+		return feemanager.NewConfig(timestamp, admins, enableds, precompile.InitialFeeConfig), nil
+	case nativeminter.ConfigKey:
+		if precompile.Disable {
+			return nativeminter.NewDisableConfig(timestamp), nil
+		}
+		var initialMint map[common.Address]*math.HexOrDecimal256
+		if len(precompile.InitialMint) > 0 {
+			initialMint = map[common.Address]*math.HexOrDecimal256{}
+			for address, amountStr := range precompile.InitialMint {
+				amount, ok := math.ParseBig256(amountStr)
+				if !ok {
+					return nil, fmt.Errorf("invalid %s amount %q for %s", initialMintKey, amountStr, address)
+				}
+				initialMint[address] = (*math.HexOrDecimal256)(amount)
+			}
+		}
+		return nativeminter.NewConfig(timestamp, admins, enableds, initialMint), nil
+	case rewardmanager.ConfigKey:
+		if precompile.Disable {
+			return rewardmanager.NewDisableConfig(timestamp), nil
+		}
+		return rewardmanager.NewConfig(timestamp, admins, enableds, precompile.InitialRewardConfig), nil
+	}
+	return nil, fmt.Errorf("unknown precompile, expected one of %s", strings.Join([]string{
+		deployerallowlist.ConfigKey,
+		txallowlist.ConfigKey,
+		feemanager.ConfigKey,
+		nativeminter.ConfigKey,
+		rewardmanager.ConfigKey,
+	}, ", "))
+}
+
+// upgradeGenerateFromSpec generates the upgrade file of [subnetName] from the spec
+// at [specPath]. The upgrades already applied, as recorded in the lock file, are
+// kept, and the ones of the spec are appended to them
+func upgradeGenerateFromSpec(subnetName string, specPath string) error {
+	spec, err := loadUpgradeSpec(specPath)
+	if err != nil {
+		return err
+	}
+	newUpgrades, err := buildPrecompileUpgrades(spec, time.Now())
+	if err != nil {
+		return err
+	}
+
+	lockBytes, err := app.ReadLockUpgradeFile(subnetName)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	upgradeConfig := params.UpgradeConfig{}
+	if len(lockBytes) > 0 {
+		upgradeConfig, err = getUpgradeConfig(lockBytes)
+		if err != nil {
+			return err
+		}
+	}
+	upgradeConfig.PrecompileUpgrades = append(upgradeConfig.PrecompileUpgrades, newUpgrades...)
+
+	// check the upgrades against the genesis, the same way the VM does
+	genesis, err := app.LoadEvmGenesis(subnetName)
+	if err != nil {
+		return err
+	}
+	genesis.Config.UpgradeConfig = upgradeConfig
+	if err := genesis.Config.Verify(); err != nil {
+		return err
+	}
+
+	jsonBytes, err := json.Marshal(&upgradeConfig)
+	if err != nil {
+		return err
+	}
+	// the lock file upgrades must be kept, and timestamps must be valid
+	if _, err := validateUpgradeBytes(jsonBytes, lockBytes, true); err != nil {
+		return err
+	}
+
+	var currentConfig params.UpgradeConfig
+	if currentBytes, err := app.ReadUpgradeFile(subnetName); err == nil {
+		// an invalid current file is just replaced
+		currentConfig, _ = getUpgradeConfig(currentBytes)
+	}
+	diff, removed, err := diffUpgrades(currentConfig, upgradeConfig)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Changes to the upgrade file of %s:", subnetName)
+	for _, line := range diff {
+		ux.Logger.PrintToUser(line)
+	}
+	// upgrades of the current file that are not locked yet are not in the spec
+	if removed > 0 && !force {
+		yes, err := app.Prompt.CaptureYesNo(fmt.Sprintf(
+			"%d upgrades of the current upgrade file would be removed. Do you want to continue (use --force to skip prompting)?", removed))
+		if err != nil {
+			return err
+		}
+		if !yes {
+			ux.Logger.PrintToUser("The upgrade file of %s was not changed", subnetName)
+			return nil
+		}
+	}
+	return app.WriteUpgradeFile(subnetName, jsonBytes)
+}
+
+// diffUpgrades lists the precompile and state upgrades of [current] and [upgradeConfig],
+// prefixed with "-" if they were removed, "+" if they were added, or spaces if kept.
+// Also returns the number of removed upgrades
+func diffUpgrades(current, upgradeConfig params.UpgradeConfig) ([]string, int, error) {
+	lines, removed, err := diffUpgradeList(current.PrecompileUpgrades, upgradeConfig.PrecompileUpgrades, "")
+	if err != nil {
+		return nil, 0, err
+	}
+	stateLines, stateRemoved, err := diffUpgradeList(current.StateUpgrades, upgradeConfig.StateUpgrades, "stateUpgrade ")
+	if err != nil {
+		return nil, 0, err
+	}
+	return append(lines, stateLines...), removed + stateRemoved, nil
+}
+
+func diffUpgradeList[T any](current, upgrades []T, label string) ([]string, int, error) {
+	contains := func(list []T, upgrade T) bool {
+		for _, u := range list {
+			if reflect.DeepEqual(u, upgrade) {
+				return true
+			}
+		}
+		return false
+	}
+	lines := []string{}
+	add := func(prefix string, upgrade T) error {
+		upgradeBytes, err := json.Marshal(&upgrade)
+		if err != nil {
+			return err
+		}
+		line := fmt.Sprintf("%s %s%s", prefix, label, upgradeBytes)
+		switch prefix {
+		case "-":
+			line = logging.Red.Wrap(line)
+		case "+":
+			line = logging.Green.Wrap(line)
+		}
+		lines = append(lines, line)
+		return nil
+	}
+	removed := 0
+	for _, upgrade := range current {
+		if !contains(upgrades, upgrade) {
+			if err := add("-", upgrade); err != nil {
+				return nil, 0, err
+			}
+			removed++
+		}
+	}
+	for _, upgrade := range upgrades {
+		prefix := "+"
+		if contains(current, upgrade) {
+			prefix = " "
+		}
+		if err := add(prefix, upgrade); err != nil {
+			return nil, 0, err
+		}
+	}
+	return lines, removed, nil
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package upgradecmd
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpecAddress = "0xb794F5eA0ba39494cE839613fffBA74279579268"
+
+func writeSpec(t *testing.T, spec string) string {
+	path := filepath.Join(t.TempDir(), "upgrade-spec.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(spec), 0o600))
+	return path
+}
+
+func TestParseSpecTimestamp(t *testing.T) {
+	require := require.New(t)
+	now := time.Unix(1700000000, 0)
+
+	ts, err := parseSpecTimestamp("+48h", now)
+	require.NoError(err)
+	require.Equal(now.Add(48*time.Hour), ts)
+
+	ts, err = parseSpecTimestamp("2030-01-02 15:04:05", now)
+	require.NoError(err)
+	require.Equal(time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC), ts)
+
+	ts, err = parseSpecTimestamp(float64(1800000000), now)
+	require.NoError(err)
+	require.Equal(int64(1800000000), ts.Unix())
+
+	_, err = parseSpecTimestamp("+2 days", now)
+	require.ErrorContains(err, "invalid timestamp offset")
+	_, err = parseSpecTimestamp("tomorrow", now)
+	require.ErrorContains(err, "invalid timestamp")
+	_, err = parseSpecTimestamp(nil, now)
+	require.ErrorContains(err, "missing timestamp")
+}
+
+func TestBuildPrecompileUpgrades(t *testing.T) {
+	require := require.New(t)
+	now := time.Now()
+
+	spec, err := loadUpgradeSpec(writeSpec(t, `
+upgrades:
+  - precompile: txAllowListConfig
+    timestamp: "+1h"
+    adminAddresses: ["`+testSpecAddress+`"]
+  - precompile: contractNativeMinterConfig
+    timestamp: "+2h"
+    enabledAddresses: ["`+testSpecAddress+`"]
+    initialMint:
+      "`+testSpecAddress+`": "1000"
+  - precompile: txAllowListConfig
+    disable: true
+    timestamp: "+3h"
+`))
+	require.NoError(err)
+	upgrades, err := buildPrecompileUpgrades(spec, now)
+	require.NoError(err)
+	require.Len(upgrades, 3)
+
+	txAllowList, ok := upgrades[0].Config.(*txallowlist.Config)
+	require.True(ok)
+	require.Equal([]common.Address{common.HexToAddress(testSpecAddress)}, txAllowList.AdminAddresses)
+	require.Equal(uint64(now.Add(time.Hour).Unix()), *txAllowList.Timestamp())
+
+	minter, ok := upgrades[1].Config.(*nativeminter.Config)
+	require.True(ok)
+	require.Equal(big.NewInt(1000), (*big.Int)(minter.InitialMint[common.HexToAddress(testSpecAddress)]))
+
+	require.True(upgrades[2].Config.IsDisabled())
+
+	// the generated upgrades must be valid upgrade file contents
+	jsonBytes, err := json.Marshal(&params.UpgradeConfig{PrecompileUpgrades: upgrades})
+	require.NoError(err)
+	_, err = validateUpgradeBytes(jsonBytes, nil, true)
+	require.NoError(err)
+}
+
+func TestBuildPrecompileUpgradesErrors(t *testing.T) {
+	require := require.New(t)
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		spec     precompileSpec
+		errorMsg string
+	}{
+		{
+			name:     "unknown precompile",
+			spec:     precompileSpec{Precompile: "foo", Timestamp: "+1h", AdminAddresses: []common.Address{{}}},
+			errorMsg: "unknown precompile",
+		},
+		{
+			name:     "past timestamp",
+			spec:     precompileSpec{Precompile: txallowlist.ConfigKey, Timestamp: "2020-01-02 15:04:05", AdminAddresses: []common.Address{{}}},
+			errorMsg: "is not in the future",
+		},
+		{
+			name:     "no addresses",
+			spec:     precompileSpec{Precompile: txallowlist.ConfigKey, Timestamp: "+1h"},
+			errorMsg: "at least one of",
+		},
+		{
+			name:     "disable with addresses",
+			spec:     precompileSpec{Precompile: txallowlist.ConfigKey, Disable: true, Timestamp: "+1h", AdminAddresses: []common.Address{{}}},
+			errorMsg: "a disable upgrade",
+		},
+		{
+			name:     "mint on another precompile",
+			spec:     precompileSpec{Precompile: feemanager.ConfigKey, Timestamp: "+1h", AdminAddresses: []common.Address{{}}, InitialMint: map[common.Address]string{{}: "1"}},
+			errorMsg: "is only valid for",
+		},
+		{
+			name:     "invalid mint amount",
+			spec:     precompileSpec{Precompile: nativeminter.ConfigKey, Timestamp: "+1h", AdminAddresses: []common.Address{{}}, InitialMint: map[common.Address]string{{}: "lots"}},
+			errorMsg: "invalid initialMint amount",
+		},
+	}
+	for _, tt := range tests {
+		_, err := buildPrecompileUpgrades(upgradeSpec{Upgrades: []precompileSpec{tt.spec}}, now)
+		require.ErrorContains(err, tt.errorMsg, tt.name)
+	}
+}
+
+func TestDiffUpgrades(t *testing.T) {
+	require := require.New(t)
+	ts := uint64(time.Now().Add(time.Hour).Unix())
+	kept := params.PrecompileUpgrade{Config: txallowlist.NewConfig(&ts, []common.Address{{}}, nil)}
+	removed := params.PrecompileUpgrade{Config: feemanager.NewConfig(&ts, []common.Address{{}}, nil, nil)}
+	added := params.PrecompileUpgrade{Config: nativeminter.NewConfig(&ts, []common.Address{{}}, nil, nil)}
+
+	lines, removedCount, err := diffUpgrades(
+		params.UpgradeConfig{PrecompileUpgrades: []params.PrecompileUpgrade{kept, removed}},
+		params.UpgradeConfig{PrecompileUpgrades: []params.PrecompileUpgrade{kept, added}},
+	)
+	require.NoError(err)
+	require.Equal(1, removedCount)
+	require.Len(lines, 3)
+	require.Contains(lines[0], "- {\"feeManagerConfig\"")
+	require.Contains(lines[1], "  {\"txAllowListConfig\"")
+	require.Contains(lines[2], "+ {\"contractNativeMinterConfig\"")
+}
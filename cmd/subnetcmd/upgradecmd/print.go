@@ -15,10 +15,13 @@ func newUpgradePrintCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "print [subnetName]",
 		Short: "Print the upgrade.json file content",
-		Long:  `Print the upgrade.json file content`,
-		RunE:  upgradePrintCmd,
-		Args:  cobra.ExactArgs(1),
+		Long: `Print the upgrade.json file content, followed by a table of the accounts
+changed by its state upgrades, if any. With --local or --rpc, the table also shows the
+current code hash of each account on that chain.`,
+		RunE: upgradePrintCmd,
+		Args: cobra.ExactArgs(1),
 	}
+	addStateUpgradeRPCFlags(cmd)
 
 	return cmd
 }
@@ -40,5 +43,14 @@ func upgradePrintCmd(_ *cobra.Command, args []string) error {
 		return err
 	}
 	ux.Logger.PrintToUser(prettyJSON.String())
+
+	upgradeConfig, err := getUpgradeConfig(fileBytes)
+	if err != nil {
+		return err
+	}
+	if len(upgradeConfig.StateUpgrades) > 0 {
+		ux.Logger.PrintToUser("State upgrades:")
+		return printStateUpgrades(subnetName, upgradeConfig.StateUpgrades)
+	}
 	return nil
 }
@@ -0,0 +1,375 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package upgradecmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+const stateUpgradeOption = "State upgrade (change balances, code or storage)"
+
+var (
+	stateUpgradeRPCURL      string
+	stateUpgradeRPCName     string
+	stateUpgradeRPCResolved bool
+)
+
+var (
+	errStateUpgradeNotChecksummed = errors.New("state upgrade address is not checksummed")
+	errStateUpgradeNoAccounts     = errors.New("state upgrade has no accounts")
+	errStateUpgradeNoChanges      = errors.New("state upgrade account has no changes")
+	errStateUpgradeOrder          = errors.New("state upgrade timestamps must be increasing")
+)
+
+// validateStateUpgrades checks the [stateUpgrades] decoded from the upgrade [file]:
+// accounts must be given as checksummed addresses, and must be changed, and the
+// upgrades must be ordered by timestamp, as subnet-evm requires
+func validateStateUpgrades(file []byte, stateUpgrades []params.StateUpgrade) error {
+	// addresses are checked before decoding, which drops their checksum
+	var rawConfig struct {
+		StateUpgrades []struct {
+			Accounts map[string]json.RawMessage `json:"accounts"`
+		} `json:"stateUpgrades"`
+	}
+	if err := json.Unmarshal(file, &rawConfig); err != nil {
+		cause := fmt.Errorf("failed parsing JSON: %w", err)
+		return fmt.Errorf(cause.Error()+" - %w ", errInvalidPrecompiles)
+	}
+	for i, upgrade := range rawConfig.StateUpgrades {
+		for addressStr := range upgrade.Accounts {
+			if !evm.IsChecksummedAddress(addressStr) {
+				return fmt.Errorf("stateUpgrades[%d]: %w: %s, expected %s",
+					i, errStateUpgradeNotChecksummed, addressStr, common.HexToAddress(addressStr).Hex())
+			}
+		}
+	}
+
+	var previousTimestamp uint64
+	for i, upgrade := range stateUpgrades {
+		ts, err := validateTimestamp(upgrade.BlockTimestamp)
+		if err != nil {
+			return fmt.Errorf("stateUpgrades[%d]: %w", i, err)
+		}
+		if uint64(ts) <= previousTimestamp {
+			return fmt.Errorf("stateUpgrades[%d]: %w", i, errStateUpgradeOrder)
+		}
+		previousTimestamp = uint64(ts)
+		if len(upgrade.StateUpgradeAccounts) == 0 {
+			return fmt.Errorf("stateUpgrades[%d]: %w", i, errStateUpgradeNoAccounts)
+		}
+		for address, account := range upgrade.StateUpgradeAccounts {
+			if account.Code == nil && len(account.Storage) == 0 && account.BalanceChange == nil {
+				return fmt.Errorf("stateUpgrades[%d]: %w: %s", i, errStateUpgradeNoChanges, address.Hex())
+			}
+		}
+	}
+	return nil
+}
+
+// addStateUpgradeRPCFlags adds the flags selecting the chain RPC used to show the
+// current code of the accounts changed by state upgrades
+func addStateUpgradeRPCFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&useLocal, "local", false, "show the current account codes of the `local` deployment")
+	cmd.Flags().StringVar(&stateUpgradeRPCURL, "rpc", "", "show the current account codes from this chain RPC URL")
+}
+
+// getStateUpgradeRPCURL returns a name of the chain RPC of [subnetName] selected
+// with --local or --rpc, and its URL. If none was selected and [prompt] is set,
+// the user is asked for it. Returns an empty URL if no RPC was selected
+func getStateUpgradeRPCURL(subnetName string, prompt bool) (string, string, error) {
+	if stateUpgradeRPCResolved {
+		return stateUpgradeRPCName, stateUpgradeRPCURL, nil
+	}
+	if useLocal && stateUpgradeRPCURL != "" {
+		return "", "", errors.New("--local and --rpc are mutually exclusive")
+	}
+	localOption := "The local deployment"
+	rpcOption := "A node that tracks the chain (enter its RPC URL)"
+	noneOption := "None, don't show the current account codes"
+	if !useLocal && stateUpgradeRPCURL == "" && prompt {
+		options := []string{rpcOption, noneOption}
+		if sc, err := app.LoadSidecar(subnetName); err == nil && sc.Networks[models.Local.String()].BlockchainID != ids.Empty {
+			options = append([]string{localOption}, options...)
+		}
+		option, err := app.Prompt.CaptureList(
+			"Which chain RPC should be used to show the current account codes?",
+			options,
+		)
+		if err != nil {
+			return "", "", err
+		}
+		switch option {
+		case localOption:
+			useLocal = true
+		case rpcOption:
+			stateUpgradeRPCURL, err = app.Prompt.CaptureString("Chain RPC URL")
+			if err != nil {
+				return "", "", err
+			}
+		}
+	}
+	stateUpgradeRPCResolved = true
+	switch {
+	case useLocal:
+		sc, err := app.LoadSidecar(subnetName)
+		if err != nil {
+			return "", "", err
+		}
+		blockchainID := sc.Networks[models.Local.String()].BlockchainID
+		if blockchainID == ids.Empty {
+			return "", "", fmt.Errorf("%s has not been deployed to %s", subnetName, models.Local)
+		}
+		endpoint, err := models.Local.Endpoint()
+		if err != nil {
+			return "", "", err
+		}
+		stateUpgradeRPCName = models.Local.String()
+		stateUpgradeRPCURL = evm.GetRPCURL(endpoint, blockchainID)
+	case stateUpgradeRPCURL != "":
+		stateUpgradeRPCName = stateUpgradeRPCURL
+	default:
+		return "", "", nil
+	}
+	if _, err := evm.GetChainConfig(stateUpgradeRPCURL); err != nil {
+		return "", "", fmt.Errorf("the chain RPC %s is not reachable: %w", stateUpgradeRPCURL, err)
+	}
+	return stateUpgradeRPCName, stateUpgradeRPCURL, nil
+}
+
+func promptStateUpgradeParams(subnetName string, stateUpgrades *[]params.StateUpgrade) error {
+	date, err := queryActivationTimestamp()
+	if err != nil {
+		return err
+	}
+	rpcName, rpcURL, err := getStateUpgradeRPCURL(subnetName, true)
+	if err != nil {
+		return err
+	}
+
+	accounts := map[common.Address]params.StateUpgradeAccount{}
+	for {
+		addressStr, err := app.Prompt.CaptureString("Checksummed address of the account to change")
+		if err != nil {
+			return err
+		}
+		if !evm.IsChecksummedAddress(addressStr) {
+			if common.IsHexAddress(addressStr) {
+				ux.Logger.PrintToUser("The address is not checksummed. Double check it, its checksummed form is %s",
+					common.HexToAddress(addressStr).Hex())
+			} else {
+				ux.Logger.PrintToUser("Invalid address %q", addressStr)
+			}
+			continue
+		}
+		address := common.HexToAddress(addressStr)
+		if rpcURL != "" {
+			codeHash, err := evm.GetCodeHash(rpcURL, address)
+			if err != nil {
+				return err
+			}
+			ux.Logger.PrintToUser("Current code hash of %s on %s: %s", address.Hex(), rpcName, formatCodeHash(codeHash))
+		}
+		account, err := promptStateUpgradeAccount()
+		if err != nil {
+			return err
+		}
+		if account.Code == nil && len(account.Storage) == 0 && account.BalanceChange == nil {
+			ux.Logger.PrintToUser("No changes for %s, skipping it", address.Hex())
+		} else {
+			accounts[address] = account
+		}
+		yes, err := app.Prompt.CaptureNoYes("Change another account?")
+		if err != nil {
+			return err
+		}
+		if !yes {
+			break
+		}
+	}
+	if len(accounts) == 0 {
+		return errStateUpgradeNoAccounts
+	}
+	*stateUpgrades = append(*stateUpgrades, params.StateUpgrade{
+		BlockTimestamp:       utils.NewUint64(uint64(date.Unix())),
+		StateUpgradeAccounts: accounts,
+	})
+	return nil
+}
+
+func promptStateUpgradeAccount() (params.StateUpgradeAccount, error) {
+	account := params.StateUpgradeAccount{}
+
+	yes, err := app.Prompt.CaptureNoYes("Change the balance of this account?")
+	if err != nil {
+		return account, err
+	}
+	if yes {
+		for {
+			changeStr, err := app.Prompt.CaptureString("Balance change, in wei (negative to decrease it)")
+			if err != nil {
+				return account, err
+			}
+			change, ok := new(big.Int).SetString(changeStr, 10)
+			if !ok || change.Sign() == 0 {
+				ux.Logger.PrintToUser("Invalid balance change %q, expected a non zero integer", changeStr)
+				continue
+			}
+			account.BalanceChange = (*math.HexOrDecimal256)(change)
+			break
+		}
+	}
+
+	yes, err = app.Prompt.CaptureNoYes("Replace the code of this account?")
+	if err != nil {
+		return account, err
+	}
+	if yes {
+		codePath, err := app.Prompt.CaptureExistingFilepath("Path to a file with the hex encoded runtime bytecode")
+		if err != nil {
+			return account, err
+		}
+		code, err := readBytecodeFile(codePath)
+		if err != nil {
+			return account, err
+		}
+		account.Code = code
+		ux.Logger.PrintToUser("New code hash: %s", crypto.Keccak256Hash(code).Hex())
+	}
+
+	yes, err = app.Prompt.CaptureNoYes("Change the storage of this account?")
+	if err != nil {
+		return account, err
+	}
+	for yes {
+		slot, err := captureHash("Storage slot, as 32 bytes hex")
+		if err != nil {
+			return account, err
+		}
+		value, err := captureHash("Storage value, as 32 bytes hex")
+		if err != nil {
+			return account, err
+		}
+		if account.Storage == nil {
+			account.Storage = map[common.Hash]common.Hash{}
+		}
+		account.Storage[slot] = value
+		yes, err = app.Prompt.CaptureNoYes("Change another storage slot?")
+		if err != nil {
+			return account, err
+		}
+	}
+	return account, nil
+}
+
+// readBytecodeFile reads hex encoded bytecode, with or without 0x prefix, from [path]
+func readBytecodeFile(path string) (hexutil.Bytes, error) {
+	codeBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	codeStr := strings.TrimSpace(string(codeBytes))
+	if !strings.HasPrefix(codeStr, "0x") {
+		codeStr = "0x" + codeStr
+	}
+	code, err := hexutil.Decode(codeStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytecode in %s: %w", path, err)
+	}
+	return code, nil
+}
+
+func captureHash(promptStr string) (common.Hash, error) {
+	for {
+		hashStr, err := app.Prompt.CaptureString(promptStr)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		hashBytes, err := hexutil.Decode(hashStr)
+		if err != nil || len(hashBytes) != common.HashLength {
+			ux.Logger.PrintToUser("Invalid value %q, expected 0x followed by 64 hex characters", hashStr)
+			continue
+		}
+		return common.BytesToHash(hashBytes), nil
+	}
+}
+
+func formatCodeHash(codeHash common.Hash) string {
+	if codeHash == types.EmptyCodeHash {
+		return "no code"
+	}
+	return codeHash.Hex()
+}
+
+// printStateUpgrades prints a row per account changed by [stateUpgrades], along with
+// the current code hash of the account if a chain RPC was selected
+func printStateUpgrades(subnetName string, stateUpgrades []params.StateUpgrade) error {
+	rpcName, rpcURL, err := getStateUpgradeRPCURL(subnetName, false)
+	if err != nil {
+		return err
+	}
+	header := []string{"Timestamp", "Account", "Balance Change", "New Code Hash", "Storage Slots"}
+	if rpcURL != "" {
+		header = append(header, fmt.Sprintf("Current Code Hash (%s)", rpcName))
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(header)
+	table.SetRowLine(true)
+	for _, upgrade := range stateUpgrades {
+		timestamp := ""
+		if upgrade.BlockTimestamp != nil {
+			timestamp = time.Unix(int64(*upgrade.BlockTimestamp), 0).UTC().Format(constants.TimeParseLayout)
+		}
+		addresses := make([]common.Address, 0, len(upgrade.StateUpgradeAccounts))
+		for address := range upgrade.StateUpgradeAccounts {
+			addresses = append(addresses, address)
+		}
+		sort.Slice(addresses, func(i, j int) bool {
+			return addresses[i].Hex() < addresses[j].Hex()
+		})
+		for _, address := range addresses {
+			account := upgrade.StateUpgradeAccounts[address]
+			balanceChange := "-"
+			if account.BalanceChange != nil {
+				balanceChange = (*big.Int)(account.BalanceChange).String()
+			}
+			newCodeHash := "-"
+			if account.Code != nil {
+				newCodeHash = formatCodeHash(crypto.Keccak256Hash(account.Code))
+			}
+			row := []string{timestamp, address.Hex(), balanceChange, newCodeHash, strconv.Itoa(len(account.Storage))}
+			if rpcURL != "" {
+				codeHash, err := evm.GetCodeHash(rpcURL, address)
+				if err != nil {
+					return err
+				}
+				row = append(row, formatCodeHash(codeHash))
+			}
+			table.Append(row)
+		}
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,416 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package upgradecmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/cmd/flags"
+	"github.com/ava-labs/avalanche-cli/pkg/ansible"
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-network-runner/server"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	genesisStatus = "genesis"
+	appliedStatus = "applied"
+	pendingStatus = "pending"
+)
+
+var timelineEndpoints []string
+
+// timelineEntry is a precompile config of the genesis, or a precompile or state upgrade
+type timelineEntry struct {
+	Timestamp    *uint64
+	Change       string
+	Status       string
+	genesisKey   string
+	precompile   *params.PrecompileUpgrade
+	stateUpgrade *params.StateUpgrade
+}
+
+// avalanche subnet upgrade timeline
+func newUpgradeTimelineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "timeline [subnetName]",
+		Short: "Print when each precompile and state upgrade goes into effect",
+		Long: `The subnet upgrade timeline command prints in chronological order the precompiles
+enabled at genesis, the upgrades already applied (from the lock file) and the pending
+ones of the upgrade file, along with the time remaining until each activation.
+
+With --local, --fuji or --mainnet, the live chain config of the deployment is queried
+to confirm which upgrades each node has actually loaded. On the local network all the
+nodes are queried. The public API doesn't serve subnet chains, so on Fuji and Mainnet
+the nodes must be given with --endpoint, or with --cluster for the nodes of a cluster,
+which validate the Fuji deployment.`,
+		RunE:         upgradeTimelineCmd,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVar(&useLocal, "local", false, "check the upgrades loaded by the `local` deployment")
+	cmd.Flags().BoolVar(&useFuji, "fuji", false, "check the upgrades loaded by the `fuji` deployment (alias for `testnet`)")
+	cmd.Flags().BoolVar(&useFuji, "testnet", false, "check the upgrades loaded by the `testnet` deployment (alias for `fuji`)")
+	cmd.Flags().BoolVar(&useMainnet, "mainnet", false, "check the upgrades loaded by the `mainnet` deployment")
+	cmd.Flags().StringSliceVar(&timelineEndpoints, "endpoint", nil, "API endpoints of the nodes to query (fuji/mainnet)")
+	cmd.Flags().StringVar(&clusterName, "cluster", "", "query the nodes of the given `cluster` (fuji)")
+	return cmd
+}
+
+func upgradeTimelineCmd(_ *cobra.Command, args []string) error {
+	subnetName := args[0]
+	if !app.GenesisExists(subnetName) {
+		ux.Logger.PrintToUser("The provided subnet name %q does not exist", subnetName)
+		return nil
+	}
+	if !flags.EnsureMutuallyExclusive([]bool{useLocal, useFuji, useMainnet}) {
+		return errors.New("--local, --fuji and --mainnet are mutually exclusive")
+	}
+	genesis, err := app.LoadEvmGenesis(subnetName)
+	if err != nil {
+		return err
+	}
+	lockConfig, err := readUpgradeConfig(app.ReadLockUpgradeFile(subnetName))
+	if err != nil {
+		return err
+	}
+	currentConfig, err := readUpgradeConfig(app.ReadUpgradeFile(subnetName))
+	if err != nil {
+		return err
+	}
+	entries := buildTimeline(genesis.Config.GenesisPrecompiles, lockConfig, currentConfig)
+
+	network := models.Undefined
+	switch {
+	case useLocal:
+		network = models.Local
+	case useFuji:
+		network = models.Fuji
+	case useMainnet:
+		network = models.Mainnet
+	}
+	if clusterName != "" {
+		if len(timelineEndpoints) > 0 {
+			return errors.New("--endpoint and --cluster are mutually exclusive")
+		}
+		if network != models.Undefined && network != models.Fuji {
+			return errors.New("the nodes of a cluster validate the fuji deployment")
+		}
+		network = models.Fuji
+	}
+	nodes := []string{}
+	nodeConfigs := map[string]*params.ChainConfigWithUpgradesJSON{}
+	if network != models.Undefined {
+		sc, err := app.LoadSidecar(subnetName)
+		if err != nil {
+			return err
+		}
+		blockchainID := sc.Networks[network.String()].BlockchainID
+		if blockchainID == ids.Empty {
+			return fmt.Errorf("%s has not been deployed to %s", subnetName, network)
+		}
+		var nodeErrs map[string]error
+		nodeConfigs, nodeErrs, err = getNodeChainConfigs(network, blockchainID)
+		if err != nil {
+			return err
+		}
+		for node := range nodeConfigs {
+			nodes = append(nodes, node)
+		}
+		for node := range nodeErrs {
+			nodes = append(nodes, node)
+		}
+		sort.Strings(nodes)
+		defer func() {
+			for _, node := range nodes {
+				if err, ok := nodeErrs[node]; ok {
+					ux.Logger.PrintToUser("Failed to get the chain config from %s: %s", node, err)
+				}
+			}
+		}()
+	}
+
+	printTimeline(entries, nodes, nodeConfigs, time.Now())
+	return nil
+}
+
+// readUpgradeConfig parses the upgrade file returned by a read function, allowing
+// it to not exist
+func readUpgradeConfig(file []byte, err error) (params.UpgradeConfig, error) {
+	if err != nil {
+		if os.IsNotExist(err) {
+			return params.UpgradeConfig{}, nil
+		}
+		return params.UpgradeConfig{}, err
+	}
+	return getUpgradeConfig(file)
+}
+
+// buildTimeline returns the genesis precompiles, the applied upgrades of [lockConfig]
+// and the pending ones of [currentConfig], in chronological order
+func buildTimeline(genesisPrecompiles params.Precompiles, lockConfig, currentConfig params.UpgradeConfig) []timelineEntry {
+	entries := []timelineEntry{}
+	genesisKeys := make([]string, 0, len(genesisPrecompiles))
+	for key := range genesisPrecompiles {
+		genesisKeys = append(genesisKeys, key)
+	}
+	sort.Strings(genesisKeys)
+	for _, key := range genesisKeys {
+		entries = append(entries, timelineEntry{
+			Timestamp:  genesisPrecompiles[key].Timestamp(),
+			Change:     "enable " + key,
+			Status:     genesisStatus,
+			genesisKey: key,
+		})
+	}
+	addPrecompileUpgrades := func(upgrades []params.PrecompileUpgrade, status string) {
+		for i := range upgrades {
+			upgrade := upgrades[i]
+			if status == pendingStatus && containsAllUpgrades(lockConfig.PrecompileUpgrades, []params.PrecompileUpgrade{upgrade}) {
+				continue
+			}
+			change := "enable " + upgrade.Key()
+			if upgrade.IsDisabled() {
+				change = "disable " + upgrade.Key()
+			}
+			entries = append(entries, timelineEntry{
+				Timestamp:  upgrade.Timestamp(),
+				Change:     change,
+				Status:     status,
+				precompile: &upgrade,
+			})
+		}
+	}
+	addStateUpgrades := func(upgrades []params.StateUpgrade, status string) {
+		for i := range upgrades {
+			upgrade := upgrades[i]
+			if status == pendingStatus && containsAllUpgrades(lockConfig.StateUpgrades, []params.StateUpgrade{upgrade}) {
+				continue
+			}
+			entries = append(entries, timelineEntry{
+				Timestamp:    upgrade.BlockTimestamp,
+				Change:       fmt.Sprintf("state upgrade of %d accounts", len(upgrade.StateUpgradeAccounts)),
+				Status:       status,
+				stateUpgrade: &upgrade,
+			})
+		}
+	}
+	addPrecompileUpgrades(lockConfig.PrecompileUpgrades, appliedStatus)
+	addStateUpgrades(lockConfig.StateUpgrades, appliedStatus)
+	addPrecompileUpgrades(currentConfig.PrecompileUpgrades, pendingStatus)
+	addStateUpgrades(currentConfig.StateUpgrades, pendingStatus)
+
+	timestamp := func(entry timelineEntry) uint64 {
+		if entry.Timestamp == nil {
+			return 0
+		}
+		return *entry.Timestamp
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if (entries[i].Status == genesisStatus) != (entries[j].Status == genesisStatus) {
+			return entries[i].Status == genesisStatus
+		}
+		return timestamp(entries[i]) < timestamp(entries[j])
+	})
+	return entries
+}
+
+// isLoaded tells if [chainConfig] contains the change of [entry]
+func (entry timelineEntry) isLoaded(chainConfig *params.ChainConfigWithUpgradesJSON) bool {
+	switch {
+	case entry.precompile != nil:
+		return containsAllUpgrades(chainConfig.UpgradeConfig.PrecompileUpgrades, []params.PrecompileUpgrade{*entry.precompile})
+	case entry.stateUpgrade != nil:
+		return containsAllUpgrades(chainConfig.UpgradeConfig.StateUpgrades, []params.StateUpgrade{*entry.stateUpgrade})
+	}
+	_, ok := chainConfig.GenesisPrecompiles[entry.genesisKey]
+	return ok
+}
+
+// getActivation describes when [entry] goes into effect, relative to [now]
+func (entry timelineEntry) getActivation(now time.Time) string {
+	if entry.Status == genesisStatus {
+		return "at genesis"
+	}
+	if entry.Timestamp == nil {
+		return "unknown"
+	}
+	activation := time.Unix(int64(*entry.Timestamp), 0)
+	if !activation.After(now) {
+		return "activated"
+	}
+	remaining := activation.Sub(now).Truncate(time.Minute)
+	if remaining < time.Minute {
+		return "in less than a minute"
+	}
+	return "in " + strings.TrimSpace(ux.FormatDuration(remaining))
+}
+
+func printTimeline(entries []timelineEntry, nodes []string, nodeConfigs map[string]*params.ChainConfigWithUpgradesJSON, now time.Time) {
+	if len(entries) == 0 {
+		ux.Logger.PrintToUser("No precompiles or upgrades configured")
+		return
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	header := []string{"Timestamp", "Change", "Status", "Activation"}
+	for _, node := range nodes {
+		header = append(header, "Loaded by "+node)
+	}
+	table.SetHeader(header)
+	table.SetRowLine(true)
+	for _, entry := range entries {
+		timestamp := ""
+		if entry.Timestamp != nil && *entry.Timestamp > 0 {
+			timestamp = time.Unix(int64(*entry.Timestamp), 0).UTC().Format(constants.TimeParseLayout)
+		}
+		row := []string{timestamp, entry.Change, entry.Status, entry.getActivation(now)}
+		for _, node := range nodes {
+			chainConfig, ok := nodeConfigs[node]
+			switch {
+			case !ok:
+				row = append(row, "unreachable")
+			case entry.isLoaded(chainConfig):
+				row = append(row, "yes")
+			default:
+				row = append(row, "no")
+			}
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+// getNodeChainConfigs returns the chain config of [blockchainID] reported by each
+// node of [network] to query, and the errors of the nodes that could not report it
+func getNodeChainConfigs(
+	network models.Network,
+	blockchainID ids.ID,
+) (map[string]*params.ChainConfigWithUpgradesJSON, map[string]error, error) {
+	if clusterName != "" {
+		return getClusterChainConfigs(clusterName, blockchainID)
+	}
+	endpoints, err := getTimelineEndpoints(network)
+	if err != nil {
+		return nil, nil, err
+	}
+	nodeConfigs := map[string]*params.ChainConfigWithUpgradesJSON{}
+	nodeErrs := map[string]error{}
+	for node, endpoint := range endpoints {
+		chainConfig, err := evm.GetChainConfig(evm.GetRPCURL(endpoint, blockchainID))
+		if err != nil {
+			nodeErrs[node] = err
+			continue
+		}
+		nodeConfigs[node] = &chainConfig
+	}
+	return nodeConfigs, nodeErrs, nil
+}
+
+// getTimelineEndpoints returns the API endpoints of the nodes of [network] to
+// query, by node name
+func getTimelineEndpoints(network models.Network) (map[string]string, error) {
+	endpoints := map[string]string{}
+	switch {
+	case len(timelineEndpoints) > 0:
+		for _, endpoint := range timelineEndpoints {
+			endpoints[endpoint] = endpoint
+		}
+		return endpoints, nil
+	case network != models.Local:
+		return nil, fmt.Errorf("the public API doesn't serve subnet chains, provide the %s nodes to query with --endpoint or --cluster", network)
+	}
+	cli, err := binutils.NewGRPCClient()
+	if err != nil {
+		return nil, err
+	}
+	status, err := cli.Status(binutils.GetAsyncContext())
+	if err != nil {
+		if server.IsServerError(err, server.ErrNotBootstrapped) {
+			return nil, errors.New(ErrNetworkNotStartedOutput)
+		}
+		return nil, err
+	}
+	for name, nodeInfo := range status.GetClusterInfo().GetNodeInfos() {
+		endpoints[name] = nodeInfo.GetUri()
+	}
+	return endpoints, nil
+}
+
+// getClusterChainConfigs returns the chain config of [blockchainID] reported by the
+// nodes of [clusterName]. Their API only listens on localhost, so it is queried
+// from the nodes themselves
+func getClusterChainConfigs(
+	clusterName string,
+	blockchainID ids.ID,
+) (map[string]*params.ChainConfigWithUpgradesJSON, map[string]error, error) {
+	if err := checkClusterExists(clusterName); err != nil {
+		return nil, nil, err
+	}
+	if err := ansible.CheckIsInstalled(); err != nil {
+		return nil, nil, err
+	}
+	// the ansible dir is rewritten in case the playbooks changed
+	if err := app.SetupAnsibleEnv(); err != nil {
+		return nil, nil, err
+	}
+	if err := ansible.Setup(app.GetAnsibleDir()); err != nil {
+		return nil, nil, err
+	}
+	// status files left by a previous run must not be taken for the nodes' ones
+	if err := app.RemoveAnsibleStatusDir(); err != nil {
+		return nil, nil, err
+	}
+	if err := os.MkdirAll(app.GetAnsibleStatusDir(), constants.DefaultPerms755); err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err := app.RemoveAnsibleStatusDir(); err != nil {
+			app.Log.Debug("failed to remove the ansible status dir", zap.Error(err))
+		}
+	}()
+	playbookErr := ansible.RunAnsiblePlaybookGetChainConfig(
+		app.GetAnsibleDir(),
+		app.GetAnsibleInventoryPath(clusterName),
+		blockchainID.String(),
+		app.GetAnsibleStatusDir(),
+	)
+	files, err := os.ReadDir(app.GetAnsibleStatusDir())
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(files) == 0 {
+		if playbookErr != nil {
+			return nil, nil, fmt.Errorf("failed to get the chain config from the nodes of cluster %s: %w", clusterName, playbookErr)
+		}
+		return nil, nil, fmt.Errorf("no node of cluster %s reported its chain config", clusterName)
+	}
+	if playbookErr != nil {
+		ux.Logger.PrintToUser("Some nodes of cluster %s could not be reached: %s", clusterName, playbookErr)
+	}
+	nodeConfigs := map[string]*params.ChainConfigWithUpgradesJSON{}
+	nodeErrs := map[string]error{}
+	for _, file := range files {
+		host := strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))
+		chainConfig, err := parseNodeChainConfig(filepath.Join(app.GetAnsibleStatusDir(), file.Name()))
+		if err != nil {
+			nodeErrs[host] = err
+			continue
+		}
+		nodeConfigs[host] = chainConfig
+	}
+	return nodeConfigs, nodeErrs, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package upgradecmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTimeline(t *testing.T) {
+	require := require.New(t)
+	now := time.Now().Truncate(time.Second)
+	admins := []common.Address{common.HexToAddress("0xb794F5eA0ba39494cE839613fffBA74279579268")}
+	applied := params.PrecompileUpgrade{
+		Config: feemanager.NewConfig(utils.NewUint64(uint64(now.Add(-time.Hour).Unix())), admins, nil, nil),
+	}
+	pendingDisable := params.PrecompileUpgrade{
+		Config: txallowlist.NewDisableConfig(utils.NewUint64(uint64(now.Add(48 * time.Hour).Unix()))),
+	}
+	pendingState := params.StateUpgrade{
+		BlockTimestamp: utils.NewUint64(uint64(now.Add(24 * time.Hour).Unix())),
+		StateUpgradeAccounts: map[common.Address]params.StateUpgradeAccount{
+			admins[0]: {Code: []byte{0x1}},
+		},
+	}
+	genesisPrecompiles := params.Precompiles{
+		txallowlist.ConfigKey: txallowlist.NewConfig(utils.NewUint64(0), admins, nil),
+	}
+	lockConfig := params.UpgradeConfig{PrecompileUpgrades: []params.PrecompileUpgrade{applied}}
+	currentConfig := params.UpgradeConfig{
+		PrecompileUpgrades: []params.PrecompileUpgrade{applied, pendingDisable},
+		StateUpgrades:      []params.StateUpgrade{pendingState},
+	}
+
+	entries := buildTimeline(genesisPrecompiles, lockConfig, currentConfig)
+	require.Len(entries, 4)
+	require.Equal("enable txAllowListConfig", entries[0].Change)
+	require.Equal(genesisStatus, entries[0].Status)
+	require.Equal("at genesis", entries[0].getActivation(now))
+	require.Equal("enable feeManagerConfig", entries[1].Change)
+	require.Equal(appliedStatus, entries[1].Status)
+	require.Equal("activated", entries[1].getActivation(now))
+	require.Equal("state upgrade of 1 accounts", entries[2].Change)
+	require.Equal(pendingStatus, entries[2].Status)
+	require.Equal("in 1 days", entries[2].getActivation(now))
+	require.Equal("disable txAllowListConfig", entries[3].Change)
+	require.Equal("in 2 days", entries[3].getActivation(now))
+
+	chainConfig := &params.ChainConfigWithUpgradesJSON{
+		ChainConfig:   params.ChainConfig{GenesisPrecompiles: genesisPrecompiles},
+		UpgradeConfig: lockConfig,
+	}
+	require.True(entries[0].isLoaded(chainConfig))
+	require.True(entries[1].isLoaded(chainConfig))
+	require.False(entries[2].isLoaded(chainConfig))
+	require.False(entries[3].isLoaded(chainConfig))
+}
@@ -99,9 +99,9 @@ func TestEarliestTimestamp(t *testing.T) {
 	require := require.New(t)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			upgrades, err := getAllUpgrades(tt.upgradesFile)
+			upgradeConfig, err := getUpgradeConfig(tt.upgradesFile)
 			require.NoError(err)
-			earliest, err := getEarliestUpcomingTimestamp(upgrades)
+			earliest, err := getEarliestUpcomingTimestamp(upgradeConfig)
 			if tt.expectedErr != nil {
 				// give some time so timestamps are defo before now
 				time.Sleep(1 * time.Second)
@@ -261,3 +261,81 @@ func TestLockFile(t *testing.T) {
 		})
 	}
 }
+
+func TestStateUpgradeValidation(t *testing.T) {
+	type testRun struct {
+		name         string
+		upgradesFile []byte
+		lockFile     []byte
+		expectedErr  error
+	}
+
+	first := time.Now().Add(1 * time.Minute).Unix()
+	second := time.Now().Add(2 * time.Minute).Unix()
+	stateUpgrade := `{"blockTimestamp":%d,"accounts":{"%s":{"balanceChange":"0x64"}}}`
+	checksummed := "0xb794F5eA0ba39494cE839613fffBA74279579268"
+
+	tests := []testRun{
+		{
+			name: "only state upgrades",
+			upgradesFile: []byte(
+				fmt.Sprintf(`{"stateUpgrades":[`+stateUpgrade+`]}`, first, checksummed)),
+			expectedErr: nil,
+		},
+		{
+			name: "not checksummed",
+			upgradesFile: []byte(
+				fmt.Sprintf(`{"stateUpgrades":[`+stateUpgrade+`]}`, first, "0xb794f5ea0ba39494ce839613fffba74279579268")),
+			expectedErr: errStateUpgradeNotChecksummed,
+		},
+		{
+			name: "no changes",
+			upgradesFile: []byte(
+				fmt.Sprintf(`{"stateUpgrades":[{"blockTimestamp":%d,"accounts":{"%s":{}}}]}`, first, checksummed)),
+			expectedErr: errStateUpgradeNoChanges,
+		},
+		{
+			name: "no accounts",
+			upgradesFile: []byte(
+				fmt.Sprintf(`{"stateUpgrades":[{"blockTimestamp":%d,"accounts":{}}]}`, first)),
+			expectedErr: errStateUpgradeNoAccounts,
+		},
+		{
+			name: "unordered",
+			upgradesFile: []byte(
+				fmt.Sprintf(`{"stateUpgrades":[`+stateUpgrade+`,`+stateUpgrade+`]}`, second, checksummed, first, checksummed)),
+			expectedErr: errStateUpgradeOrder,
+		},
+		{
+			name: "no blockTimestamp",
+			upgradesFile: []byte(
+				fmt.Sprintf(`{"stateUpgrades":[{"accounts":{"%s":{"balanceChange":"0x64"}}}]}`, checksummed)),
+			expectedErr: errNoBlockTimestamp,
+		},
+		{
+			name: "lock state upgrade kept",
+			upgradesFile: []byte(
+				fmt.Sprintf(`{"stateUpgrades":[`+stateUpgrade+`,`+stateUpgrade+`]}`, first, checksummed, second, checksummed)),
+			lockFile: []byte(
+				fmt.Sprintf(`{"stateUpgrades":[`+stateUpgrade+`]}`, first, checksummed)),
+			expectedErr: nil,
+		},
+		{
+			name: "lock state upgrade removed",
+			upgradesFile: []byte(
+				fmt.Sprintf(`{"stateUpgrades":[`+stateUpgrade+`]}`, second, checksummed)),
+			lockFile: []byte(
+				fmt.Sprintf(`{"stateUpgrades":[`+stateUpgrade+`]}`, first, checksummed)),
+			expectedErr: errNewUpgradesNotContainsLock,
+		},
+	}
+
+	skipPrompting := true
+	require := require.New(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validateUpgradeBytes(tt.upgradesFile, tt.lockFile, skipPrompting)
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
@@ -5,14 +5,18 @@ package subnetcmd
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ava-labs/avalanche-cli/cmd/flags"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
 	"github.com/ava-labs/avalanche-cli/pkg/subnet"
-	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
@@ -22,6 +26,9 @@ var (
 	validatorsLocal   bool
 	validatorsTestnet bool
 	validatorsMainnet bool
+	expiringWithin    string
+
+	errExpiringValidators = errors.New("found validators about to expire")
 )
 
 // avalanche subnet validators
@@ -30,7 +37,11 @@ func newValidatorsCmd() *cobra.Command {
 		Use:   "validators [subnetName]",
 		Short: "List a subnet's validators",
 		Long: `The subnet validators command lists the validators of a subnet and provides
-severarl statistics about them.`,
+severarl statistics about them.
+
+With --expiring-within, only the validators whose stake ends within the given
+period (e.g. 14d or 36h) are listed, and the command fails if any is found.
+Use subnet validators renew to extend them.`,
 		RunE:         printValidators,
 		Args:         cobra.ExactArgs(1),
 		SilenceUsage: true,
@@ -39,6 +50,11 @@ severarl statistics about them.`,
 	cmd.Flags().BoolVarP(&validatorsTestnet, "testnet", "t", false, "deploy to testnet (alias to `fuji`)")
 	cmd.Flags().BoolVarP(&validatorsTestnet, "fuji", "f", false, "deploy to fuji (alias to `testnet`")
 	cmd.Flags().BoolVarP(&validatorsMainnet, "mainnet", "m", false, "deploy to mainnet")
+	cmd.Flags().StringVar(&expiringWithin, "expiring-within", "", "only list the validators expiring within the given period, and fail if any")
+	// subnet validators renew
+	cmd.AddCommand(newValidatorsRenewCmd())
+	// subnet validators set-weight
+	cmd.AddCommand(newValidatorsSetWeightCmd())
 	return cmd
 }
 
@@ -82,29 +98,63 @@ func printValidators(_ *cobra.Command, args []string) error {
 
 	subnetID := deployInfo.SubnetID
 
+	var validators []platformvm.ClientPermissionlessValidator
 	if network == models.Local {
-		return printLocalValidators(subnetID)
+		validators, err = subnet.GetSubnetValidators(subnetID)
 	} else {
-		return printPublicValidators(subnetID, network)
+		validators, err = subnet.GetPublicSubnetValidators(subnetID, network)
+	}
+	if err != nil {
+		return err
 	}
-}
 
-func printLocalValidators(subnetID ids.ID) error {
-	validators, err := subnet.GetSubnetValidators(subnetID)
+	if expiringWithin == "" {
+		return printValidatorsFromList(validators)
+	}
+	within, err := parseDurationWithDays(expiringWithin)
 	if err != nil {
 		return err
 	}
+	expiring := getExpiringValidators(validators, time.Now().Add(within))
+	if len(expiring) == 0 {
+		ux.Logger.PrintToUser("No validators expiring within %s", expiringWithin)
+		return nil
+	}
+	if err := printValidatorsFromList(expiring); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %d validators expire within %s", errExpiringValidators, len(expiring), expiringWithin)
+}
 
-	return printValidatorsFromList(validators)
+// getExpiringValidators returns the [validators] whose stake ends before [deadline]
+func getExpiringValidators(
+	validators []platformvm.ClientPermissionlessValidator,
+	deadline time.Time,
+) []platformvm.ClientPermissionlessValidator {
+	expiring := []platformvm.ClientPermissionlessValidator{}
+	for _, validator := range validators {
+		if time.Unix(int64(validator.EndTime), 0).Before(deadline) {
+			expiring = append(expiring, validator)
+		}
+	}
+	return expiring
 }
 
-func printPublicValidators(subnetID ids.ID, network models.Network) error {
-	validators, err := subnet.GetPublicSubnetValidators(subnetID, network)
+// parseDurationWithDays parses a duration as time.ParseDuration does,
+// also accepting a number of days with the "d" unit (e.g. "14d")
+func parseDurationWithDays(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.ParseUint(strings.TrimSuffix(s, "d"), 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
 	if err != nil {
-		return err
+		return 0, fmt.Errorf("invalid duration %q", s)
 	}
-
-	return printValidatorsFromList(validators)
+	return d, nil
 }
 
 func printValidatorsFromList(validators []platformvm.ClientPermissionlessValidator) error {
@@ -142,3 +192,36 @@ func printValidatorsFromList(validators []platformvm.ClientPermissionlessValidat
 func formatUnixTime(unixTime uint64) string {
 	return time.Unix(int64(unixTime), 0).Format(time.RFC3339)
 }
+
+// selectFeePayer sets the key or ledger that pays the fees of the validator
+// txs on [network], prompting for it on fuji if no flag was given. It returns
+// the network to actually use, as E2E tests simulate public networks locally
+func selectFeePayer(network models.Network) (models.Network, error) {
+	var err error
+	if len(ledgerAddresses) > 0 {
+		useLedger = true
+	}
+	if useLedger && keyName != "" {
+		return models.Undefined, ErrMutuallyExlusiveKeyLedger
+	}
+	switch network {
+	case models.Fuji:
+		if !useLedger && keyName == "" {
+			useLedger, keyName, err = prompts.GetFujiKeyOrLedger(app.Prompt, "pay transaction fees", app.GetKeyDir())
+			if err != nil {
+				return models.Undefined, err
+			}
+		}
+	case models.Mainnet:
+		useLedger = true
+		if keyName != "" {
+			return models.Undefined, ErrStoredKeyOnMainnet
+		}
+	default:
+		return models.Undefined, errors.New("unsupported network")
+	}
+	if os.Getenv(constants.SimulatePublicNetwork) != "" {
+		return models.Local, nil
+	}
+	return network, nil
+}
@@ -0,0 +1,392 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnetcmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/cmd/flags"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/txutils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	renewWithin     string
+	renewPeriod     time.Duration
+	renewStartDelay time.Duration
+)
+
+// pendingRenewals are the planned renewals of single signer subnets that wait
+// for the old stake of their node to end, by network
+type pendingRenewals map[string][]batchValidator
+
+// avalanche subnet validators renew
+func newValidatorsRenewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "renew [subnetName]",
+		Short: "Re-add the subnet validators that are about to expire",
+		Long: `The subnet validators renew command re-adds the validators of a permissioned
+subnet whose stake ends within --expiring-within, with the same weight and for a new
+--staking-period. Each renewal is scheduled to start --start-delay after the old stake
+ends, and it never goes past the end of the node's primary network validation.
+
+The P-Chain doesn't accept a second stake for a node that is still validating the
+subnet, so a renewal can only be issued once the old stake ended, within --start-delay.
+The command never waits for that:
+
+- for multisig subnets, the partially signed renewal txs are saved into --output-tx-dir
+  right away. Once signed, each must be committed after the old stake ends and before
+  the renewal starts.
+- otherwise, the renewals are saved as pending with the subnet, and issued by the next
+  run of the command after the old stake ended. Schedule the command to run within
+  --start-delay of the listed stake ends, e.g. with cron.`,
+		SilenceUsage: true,
+		RunE:         renewValidators,
+		Args:         cobra.ExactArgs(1),
+	}
+	cmd.Flags().BoolVarP(&validatorsTestnet, "testnet", "t", false, "renew on testnet (alias to `fuji`)")
+	cmd.Flags().BoolVarP(&validatorsTestnet, "fuji", "f", false, "renew on fuji (alias to `testnet`")
+	cmd.Flags().BoolVarP(&validatorsMainnet, "mainnet", "m", false, "renew on mainnet")
+	cmd.Flags().StringVar(&renewWithin, "expiring-within", "14d", "renew the validators expiring within the given period")
+	cmd.Flags().DurationVar(&renewPeriod, "staking-period", 0, "staking period of the renewals (defaults to until primary network validation ends)")
+	cmd.Flags().DurationVar(&renewStartDelay, "start-delay", constants.StakingStartLeadTime, "time between the end of a stake and the start of its renewal, in which the renewal is issued")
+	cmd.Flags().StringVarP(&keyName, "key", "k", "", "select the key to use [fuji only]")
+	cmd.Flags().BoolVarP(&useLedger, "ledger", "g", false, "use ledger instead of key (always true on mainnet, defaults to false on fuji)")
+	cmd.Flags().StringSliceVar(&ledgerAddresses, "ledger-addrs", []string{}, "use the given ledger addresses")
+	cmd.Flags().StringSliceVar(&subnetAuthKeys, "subnet-auth-keys", nil, "control keys that will be used to authenticate the add validator txs")
+	cmd.Flags().StringVar(&outputTxDir, "output-tx-dir", "", "directory of the partially signed add validator txs")
+	return cmd
+}
+
+func renewValidators(_ *cobra.Command, args []string) error {
+	if !flags.EnsureMutuallyExclusive([]bool{validatorsTestnet, validatorsMainnet}) {
+		return errMutuallyExlusiveNetworks
+	}
+	var network models.Network
+	switch {
+	case validatorsTestnet:
+		network = models.Fuji
+	case validatorsMainnet:
+		network = models.Mainnet
+	}
+	if network == models.Undefined {
+		networkStr, err := app.Prompt.CaptureList(
+			"Choose a network to renew validators on",
+			[]string{models.Fuji.String(), models.Mainnet.String()},
+		)
+		if err != nil {
+			return err
+		}
+		network = models.NetworkFromString(networkStr)
+	}
+
+	within, err := parseDurationWithDays(renewWithin)
+	if err != nil {
+		return err
+	}
+	if renewStartDelay < constants.StakingStartLeadTime {
+		return fmt.Errorf("--start-delay can't be shorter than %s", constants.StakingStartLeadTime)
+	}
+
+	network, err = selectFeePayer(network)
+	if err != nil {
+		return err
+	}
+
+	chains, err := ValidateSubnetNameAndGetChains(args)
+	if err != nil {
+		return err
+	}
+	sc, err := app.LoadSidecar(chains[0])
+	if err != nil {
+		return err
+	}
+	subnetID := sc.Networks[network.String()].SubnetID
+	if subnetID == ids.Empty {
+		return errNoSubnetID
+	}
+	allPending, err := loadPendingRenewals(sc.Name)
+	if err != nil {
+		return err
+	}
+	pending := allPending[network.String()]
+
+	var validators []platformvm.ClientPermissionlessValidator
+	if network == models.Local {
+		validators, err = subnet.GetSubnetValidators(subnetID)
+	} else {
+		validators, err = subnet.GetPublicSubnetValidators(subnetID, network)
+	}
+	if err != nil {
+		return err
+	}
+	stakeEnds := map[ids.NodeID]time.Time{}
+	for _, validator := range validators {
+		stakeEnds[validator.NodeID] = time.Unix(int64(validator.EndTime), 0)
+	}
+
+	expiring := []platformvm.ClientPermissionlessValidator{}
+	for _, validator := range getExpiringValidators(validators, time.Now().Add(within)) {
+		if !hasPendingRenewal(pending, validator.NodeID) {
+			expiring = append(expiring, validator)
+		}
+	}
+	renewals := []batchValidator{}
+	if len(expiring) > 0 {
+		primaryValidators, err := getPrimaryValidatorsEndTime(network)
+		if err != nil {
+			return err
+		}
+		var problems []string
+		renewals, problems = planRenewals(expiring, primaryValidators, renewPeriod, renewStartDelay)
+		for _, problem := range problems {
+			ux.Logger.PrintToUser("Skipping %s", problem)
+		}
+	}
+	if len(renewals) == 0 && len(pending) == 0 {
+		if len(expiring) > 0 {
+			return errors.New("none of the expiring validators can be renewed")
+		}
+		ux.Logger.PrintToUser("No validators expiring within %s", renewWithin)
+		return nil
+	}
+	if len(renewals) > 0 {
+		printRenewals(renewals)
+		yes, err := app.Prompt.CaptureYesNo("Renew these validators?")
+		if err != nil {
+			return err
+		}
+		if !yes {
+			renewals = nil
+		}
+	}
+
+	controlKeys, threshold, err := txutils.GetOwners(network, subnetID)
+	if err != nil {
+		return err
+	}
+	if subnetAuthKeys != nil {
+		if err := prompts.CheckSubnetAuthKeys(subnetAuthKeys, controlKeys, threshold); err != nil {
+			return err
+		}
+	} else {
+		subnetAuthKeys, err = prompts.GetSubnetAuthKeys(app.Prompt, controlKeys, threshold)
+		if err != nil {
+			return err
+		}
+	}
+	kc, err := GetKeychain(useLedger, ledgerAddresses, keyName, network)
+	if err != nil {
+		return err
+	}
+	deployer := subnet.NewPublicDeployer(app, useLedger, kc, network)
+	fullySigned, err := deployer.HoldsSubnetAuthKeys(subnetAuthKeys)
+	if err != nil {
+		return err
+	}
+
+	if !fullySigned {
+		// multisig renewals are signed ahead, and committed by the signers in time
+		return saveRenewalTxs(deployer, sc, subnetID, controlKeys, renewals, stakeEnds)
+	}
+
+	// save the plan first, so that it survives an interrupted run
+	pending = append(pending, renewals...)
+	allPending[network.String()] = pending
+	if err := savePendingRenewals(sc.Name, allPending); err != nil {
+		return err
+	}
+	stillPending := []batchValidator{}
+	for _, renewal := range pending {
+		if stakeEnd, ok := stakeEnds[renewal.NodeID]; ok {
+			ux.Logger.PrintToUser("The renewal of %s will be issued by a run of this command between %s and %s",
+				renewal.NodeID, stakeEnd.Format(constants.TimeParseLayout), renewal.Start.Format(constants.TimeParseLayout))
+			stillPending = append(stillPending, renewal)
+			continue
+		}
+		// the run may happen after the planned start
+		if minStart := time.Now().Add(constants.StakingStartLeadTime); renewal.Start.Before(minStart) {
+			end := renewal.Start.Add(renewal.Duration)
+			if !end.After(minStart) {
+				ux.Logger.PrintToUser("Dropping the renewal of %s, which should have ended at %s", renewal.NodeID, end.Format(constants.TimeParseLayout))
+				continue
+			}
+			renewal.Start = minStart
+			renewal.Duration = end.Sub(minStart)
+		}
+		if err := addValidatorsFromFile(deployer, &sc, network, subnetID, controlKeys, []batchValidator{renewal}, outputTxDir); err != nil {
+			allPending[network.String()] = append(stillPending, pendingAfter(pending, renewal.NodeID)...)
+			if saveErr := savePendingRenewals(sc.Name, allPending); saveErr != nil {
+				return saveErr
+			}
+			return err
+		}
+	}
+	allPending[network.String()] = stillPending
+	return savePendingRenewals(sc.Name, allPending)
+}
+
+// saveRenewalTxs creates the partially signed txs of [renewals] into
+// [outputTxDir], for the signers to commit once the old stake ended
+func saveRenewalTxs(
+	deployer *subnet.PublicDeployer,
+	sc models.Sidecar,
+	subnetID ids.ID,
+	controlKeys []string,
+	renewals []batchValidator,
+	stakeEnds map[ids.NodeID]time.Time,
+) error {
+	if len(renewals) == 0 {
+		return nil
+	}
+	if outputTxDir == "" {
+		var err error
+		outputTxDir, err = app.Prompt.CaptureString("Directory to export the partially signed txs to")
+		if err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(outputTxDir, constants.DefaultPerms755); err != nil {
+		return err
+	}
+	for _, renewal := range renewals {
+		tx, err := deployer.CreateAddValidatorTx(subnetAuthKeys, subnetID, renewal.NodeID, renewal.Weight, renewal.Start, renewal.Duration)
+		if err != nil {
+			return fmt.Errorf("failed to renew %s: %w", renewal.NodeID, err)
+		}
+		_, remainingSubnetAuthKeys, err := txutils.GetRemainingSigners(tx, controlKeys)
+		if err != nil {
+			return err
+		}
+		if err := SaveNotFullySignedTx(
+			"Add Validator",
+			tx,
+			sc.Name,
+			subnetAuthKeys,
+			remainingSubnetAuthKeys,
+			filepath.Join(outputTxDir, renewal.NodeID.String()+".tx"),
+			false,
+		); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Commit the renewal of %s between %s and %s",
+			renewal.NodeID, stakeEnds[renewal.NodeID].Format(constants.TimeParseLayout), renewal.Start.Format(constants.TimeParseLayout))
+	}
+	return nil
+}
+
+func hasPendingRenewal(pending []batchValidator, nodeID ids.NodeID) bool {
+	for _, renewal := range pending {
+		if renewal.NodeID == nodeID {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingAfter returns the renewals of [pending] that follow the one of [nodeID]
+func pendingAfter(pending []batchValidator, nodeID ids.NodeID) []batchValidator {
+	for i, renewal := range pending {
+		if renewal.NodeID == nodeID {
+			return pending[i:]
+		}
+	}
+	return nil
+}
+
+func loadPendingRenewals(subnetName string) (pendingRenewals, error) {
+	renewals := pendingRenewals{}
+	renewalsBytes, err := os.ReadFile(app.GetPendingRenewalsPath(subnetName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return renewals, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(renewalsBytes, &renewals); err != nil {
+		return nil, fmt.Errorf("invalid pending renewals of %s: %w", subnetName, err)
+	}
+	return renewals, nil
+}
+
+func savePendingRenewals(subnetName string, renewals pendingRenewals) error {
+	renewalsBytes, err := json.MarshalIndent(renewals, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(app.GetPendingRenewalsPath(subnetName), renewalsBytes, constants.WriteReadReadPerms)
+}
+
+// planRenewals computes the renewal of each [expiring] validator, starting
+// [startDelay] after its stake ends and lasting [period], or until its primary
+// network validation ends if [period] is 0. Renewals can't go past the end of the primary network
+// validation. Validators that can't be renewed are described in the returned problems
+func planRenewals(
+	expiring []platformvm.ClientPermissionlessValidator,
+	primaryValidators map[ids.NodeID]time.Time,
+	period time.Duration,
+	startDelay time.Duration,
+) ([]batchValidator, []string) {
+	renewals := []batchValidator{}
+	problems := []string{}
+	for _, validator := range expiring {
+		primaryEnd, ok := primaryValidators[validator.NodeID]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: not a primary network validator anymore", validator.NodeID))
+			continue
+		}
+		start := time.Unix(int64(validator.EndTime), 0).Add(startDelay)
+		end := primaryEnd
+		if period != 0 && start.Add(period).Before(primaryEnd) {
+			end = start.Add(period)
+		}
+		if !end.After(start) {
+			problems = append(problems, fmt.Sprintf(
+				"%s: its primary network validation ends at %s",
+				validator.NodeID, primaryEnd.Format(constants.TimeParseLayout),
+			))
+			continue
+		}
+		renewals = append(renewals, batchValidator{
+			NodeID:   validator.NodeID,
+			Weight:   validator.Weight,
+			Start:    start,
+			Duration: end.Sub(start),
+		})
+	}
+	sort.Slice(renewals, func(i, j int) bool {
+		return renewals[i].Start.Before(renewals[j].Start)
+	})
+	return renewals, problems
+}
+
+func printRenewals(renewals []batchValidator) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"NodeID", "Weight", "Renewal Start", "Renewal End"})
+	table.SetRowLine(true)
+	for _, renewal := range renewals {
+		table.Append([]string{
+			renewal.NodeID.String(),
+			strconv.FormatUint(renewal.Weight, 10),
+			renewal.Start.Format(constants.TimeParseLayout),
+			renewal.Start.Add(renewal.Duration).Format(constants.TimeParseLayout),
+		})
+	}
+	table.Render()
+}
@@ -0,0 +1,389 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnetcmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/cmd/flags"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/txutils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// how often to check whether a stake already ended
+const stakeEndPollInterval = 30 * time.Second
+
+var (
+	maxWeightShare float64
+	commitWindow   time.Duration
+)
+
+// validatorShare is the weight of a validator, and its share of the total weight
+type validatorShare struct {
+	NodeID ids.NodeID
+	Weight uint64
+	Share  float64
+}
+
+// avalanche subnet validators set-weight
+func newValidatorsSetWeightCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-weight [subnetName]",
+		Short: "Change the weight of a subnet validator",
+		Long: `The subnet validators set-weight command changes the weight of a permissioned
+subnet validator, by removing it and adding it back with the new weight until its
+current end time. Before doing so, it shows the resulting weight distribution, and
+warns if a validator would hold more than --max-share percent of the total weight.
+
+The P-Chain doesn't accept a second stake for a node that still validates the subnet,
+so the node stops validating between the removal and the start of the add tx, a few
+minutes later.
+
+For multisig subnets, the partially signed remove and add txs are both saved into
+--output-tx-dir. The add tx starts at the end of --commit-window: the signers must
+commit the remove tx, then the add tx, before then. Committing the remove tx close to
+the end of the window keeps the node out of the validator set for less time.`,
+		SilenceUsage: true,
+		RunE:         setValidatorWeight,
+		Args:         cobra.ExactArgs(1),
+	}
+	cmd.Flags().BoolVarP(&validatorsTestnet, "testnet", "t", false, "set weight on testnet (alias to `fuji`)")
+	cmd.Flags().BoolVarP(&validatorsTestnet, "fuji", "f", false, "set weight on fuji (alias to `testnet`")
+	cmd.Flags().BoolVarP(&validatorsMainnet, "mainnet", "m", false, "set weight on mainnet")
+	cmd.Flags().StringVar(&nodeIDStr, "nodeID", "", "NodeID of the validator")
+	cmd.Flags().Uint64Var(&weight, "weight", 0, "new weight of the validator")
+	cmd.Flags().Float64Var(&maxWeightShare, "max-share", 33, "warn when a validator would hold more than this percentage of the total weight")
+	cmd.Flags().DurationVar(&commitWindow, "commit-window", 24*time.Hour, "time the signers of a multisig subnet have to commit the remove and add txs")
+	cmd.Flags().StringVarP(&keyName, "key", "k", "", "select the key to use [fuji only]")
+	cmd.Flags().BoolVarP(&useLedger, "ledger", "g", false, "use ledger instead of key (always true on mainnet, defaults to false on fuji)")
+	cmd.Flags().StringSliceVar(&ledgerAddresses, "ledger-addrs", []string{}, "use the given ledger addresses")
+	cmd.Flags().StringSliceVar(&subnetAuthKeys, "subnet-auth-keys", nil, "control keys that will be used to authenticate the remove and add validator txs")
+	cmd.Flags().StringVar(&outputTxDir, "output-tx-dir", "", "directory of the partially signed remove and add validator txs")
+	return cmd
+}
+
+func setValidatorWeight(_ *cobra.Command, args []string) error {
+	if !flags.EnsureMutuallyExclusive([]bool{validatorsTestnet, validatorsMainnet}) {
+		return errMutuallyExlusiveNetworks
+	}
+	var network models.Network
+	switch {
+	case validatorsTestnet:
+		network = models.Fuji
+	case validatorsMainnet:
+		network = models.Mainnet
+	}
+	if network == models.Undefined {
+		networkStr, err := app.Prompt.CaptureList(
+			"Choose a network to change the validator weight on",
+			[]string{models.Fuji.String(), models.Mainnet.String()},
+		)
+		if err != nil {
+			return err
+		}
+		network = models.NetworkFromString(networkStr)
+	}
+	network, err := selectFeePayer(network)
+	if err != nil {
+		return err
+	}
+
+	chains, err := ValidateSubnetNameAndGetChains(args)
+	if err != nil {
+		return err
+	}
+	sc, err := app.LoadSidecar(chains[0])
+	if err != nil {
+		return err
+	}
+	subnetID := sc.Networks[network.String()].SubnetID
+	if subnetID == ids.Empty {
+		return errNoSubnetID
+	}
+
+	var nodeID ids.NodeID
+	if nodeIDStr == "" {
+		nodeID, err = promptNodeID()
+	} else {
+		nodeID, err = ids.NodeIDFromString(nodeIDStr)
+	}
+	if err != nil {
+		return err
+	}
+	if weight == 0 {
+		weight, err = promptWeight()
+		if err != nil {
+			return err
+		}
+	}
+
+	var validators []platformvm.ClientPermissionlessValidator
+	if network == models.Local {
+		validators, err = subnet.GetSubnetValidators(subnetID)
+	} else {
+		validators, err = subnet.GetPublicSubnetValidators(subnetID, network)
+	}
+	if err != nil {
+		return err
+	}
+	var current *platformvm.ClientPermissionlessValidator
+	for i := range validators {
+		if validators[i].NodeID == nodeID {
+			current = &validators[i]
+		}
+	}
+	_, removed := sc.GetJournalStep(network, models.SetWeightJournal, nodeID.String())
+	if current == nil && !removed {
+		return fmt.Errorf("node %s is not a validator on subnet %s", nodeID, subnetID)
+	}
+	if current != nil && removed {
+		// the remove tx of a previous run was never committed
+		ux.Logger.PrintToUser("Node %s is still a validator, discarding the txs of a previous run", nodeID)
+		if err := app.ClearSidecarJournalStep(&sc, network, models.SetWeightJournal, nodeID.String()); err != nil {
+			return err
+		}
+	}
+	if current != nil && current.Weight == weight {
+		ux.Logger.PrintToUser("Node %s already has weight %d", nodeID, weight)
+		return nil
+	}
+
+	shares := getWeightDistribution(validators, nodeID, weight)
+	printWeightDistribution(shares, nodeID)
+	for _, share := range getOverweightValidators(shares, maxWeightShare) {
+		ux.Logger.PrintToUser(logging.Red.Wrap(fmt.Sprintf(
+			"WARNING: %s would hold %.2f%% of the total weight, more than the %.2f%% maximum",
+			share.NodeID, share.Share, maxWeightShare,
+		)))
+	}
+	yes, err := app.Prompt.CaptureYesNo(fmt.Sprintf("Set the weight of %s to %d?", nodeID, weight))
+	if err != nil {
+		return err
+	}
+	if !yes {
+		return nil
+	}
+
+	primaryValidators, err := getPrimaryValidatorsEndTime(network)
+	if err != nil {
+		return err
+	}
+	end, ok := primaryValidators[nodeID]
+	if !ok {
+		return fmt.Errorf("%s is not a primary network validator", nodeID)
+	}
+	if current != nil {
+		// keep the current end time
+		end = time.Unix(int64(current.EndTime), 0)
+	}
+
+	controlKeys, threshold, err := txutils.GetOwners(network, subnetID)
+	if err != nil {
+		return err
+	}
+	if subnetAuthKeys != nil {
+		if err := prompts.CheckSubnetAuthKeys(subnetAuthKeys, controlKeys, threshold); err != nil {
+			return err
+		}
+	} else {
+		subnetAuthKeys, err = prompts.GetSubnetAuthKeys(app.Prompt, controlKeys, threshold)
+		if err != nil {
+			return err
+		}
+	}
+	kc, err := GetKeychain(useLedger, ledgerAddresses, keyName, network)
+	if err != nil {
+		return err
+	}
+	deployer := subnet.NewPublicDeployer(app, useLedger, kc, network)
+
+	fullySigned, err := deployer.HoldsSubnetAuthKeys(subnetAuthKeys)
+	if err != nil {
+		return err
+	}
+
+	if !fullySigned {
+		if commitWindow < constants.StakingStartLeadTime {
+			return fmt.Errorf("--commit-window can't be shorter than %s", constants.StakingStartLeadTime)
+		}
+		start := time.Now().Add(commitWindow)
+		if !end.After(start) {
+			return fmt.Errorf("the validation of %s ends at %s, it can't be added back", nodeID, end.Format(constants.TimeParseLayout))
+		}
+		if current == nil {
+			// only the add tx is left
+			tx, err := deployer.CreateAddValidatorTx(subnetAuthKeys, subnetID, nodeID, weight, start, end.Sub(start))
+			if err != nil {
+				return err
+			}
+			if err := saveSetWeightTx("Add Validator", tx, sc.Name, controlKeys, nodeID, "add"); err != nil {
+				return err
+			}
+		} else {
+			removeTx, addTx, err := deployer.CreateSetWeightTxs(subnetAuthKeys, subnetID, nodeID, weight, start, end.Sub(start))
+			if err != nil {
+				return err
+			}
+			if err := saveSetWeightTx("Remove Validator", removeTx, sc.Name, controlKeys, nodeID, "remove"); err != nil {
+				return err
+			}
+			if err := saveSetWeightTx("Add Validator", addTx, sc.Name, controlKeys, nodeID, "add"); err != nil {
+				return err
+			}
+		}
+		ux.Logger.PrintToUser("")
+		ux.Logger.PrintToUser("Commit the txs in order before %s, when the add tx starts", start.Format(constants.TimeParseLayout))
+		// lets a later run know the node was meant to be removed
+		return app.UpdateSidecarJournal(&sc, network, models.SetWeightJournal, nodeID.String(), ids.Empty)
+	}
+
+	if current != nil {
+		_, tx, _, err := deployer.RemoveValidator(controlKeys, subnetAuthKeys, subnetID, nodeID)
+		if err != nil {
+			return err
+		}
+		if err := app.UpdateSidecarJournal(&sc, network, models.SetWeightJournal, nodeID.String(), tx.ID()); err != nil {
+			return err
+		}
+		if err := waitForStakeEnd(subnetID, nodeID, network, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	start := time.Now().Add(constants.StakingStartLeadTime)
+	if !end.After(start) {
+		return fmt.Errorf("the validation of %s ends at %s, it can't be added back", nodeID, end.Format(constants.TimeParseLayout))
+	}
+	_, tx, _, err := deployer.AddValidator(controlKeys, subnetAuthKeys, subnetID, nodeID, weight, start, end.Sub(start))
+	if err != nil {
+		return err
+	}
+	if err := app.UpdateSidecarJournal(&sc, network, models.AddValidatorsJournal, nodeID.String(), tx.ID()); err != nil {
+		return err
+	}
+	return app.ClearSidecarJournalStep(&sc, network, models.SetWeightJournal, nodeID.String())
+}
+
+// saveSetWeightTx saves the partially signed [tx] of [nodeID] into --output-tx-dir
+func saveSetWeightTx(txName string, tx *txs.Tx, subnetName string, controlKeys []string, nodeID ids.NodeID, fileSuffix string) error {
+	_, remainingSubnetAuthKeys, err := txutils.GetRemainingSigners(tx, controlKeys)
+	if err != nil {
+		return err
+	}
+	txPath, err := getSetWeightTxPath(nodeID, fileSuffix)
+	if err != nil {
+		return err
+	}
+	return SaveNotFullySignedTx(txName, tx, subnetName, subnetAuthKeys, remainingSubnetAuthKeys, txPath, false)
+}
+
+func getSetWeightTxPath(nodeID ids.NodeID, txName string) (string, error) {
+	if outputTxDir == "" {
+		var err error
+		outputTxDir, err = app.Prompt.CaptureString("Directory to export the partially signed txs to")
+		if err != nil {
+			return "", err
+		}
+	}
+	if err := os.MkdirAll(outputTxDir, constants.DefaultPerms755); err != nil {
+		return "", err
+	}
+	return filepath.Join(outputTxDir, fmt.Sprintf("%s-%s.tx", nodeID, txName)), nil
+}
+
+// getWeightDistribution returns the weight and share of each of the [validators]
+// once the weight of [nodeID] is set to [newWeight], sorted by decreasing weight
+func getWeightDistribution(
+	validators []platformvm.ClientPermissionlessValidator,
+	nodeID ids.NodeID,
+	newWeight uint64,
+) []validatorShare {
+	shares := []validatorShare{}
+	found := false
+	for _, validator := range validators {
+		share := validatorShare{NodeID: validator.NodeID, Weight: validator.Weight}
+		if validator.NodeID == nodeID {
+			share.Weight = newWeight
+			found = true
+		}
+		shares = append(shares, share)
+	}
+	if !found {
+		shares = append(shares, validatorShare{NodeID: nodeID, Weight: newWeight})
+	}
+	total := uint64(0)
+	for _, share := range shares {
+		total += share.Weight
+	}
+	for i := range shares {
+		shares[i].Share = 100 * float64(shares[i].Weight) / float64(total)
+	}
+	sort.SliceStable(shares, func(i, j int) bool {
+		return shares[i].Weight > shares[j].Weight
+	})
+	return shares
+}
+
+// getOverweightValidators returns the [shares] greater than [maxShare] percent
+func getOverweightValidators(shares []validatorShare, maxShare float64) []validatorShare {
+	overweight := []validatorShare{}
+	for _, share := range shares {
+		if share.Share > maxShare {
+			overweight = append(overweight, share)
+		}
+	}
+	return overweight
+}
+
+func printWeightDistribution(shares []validatorShare, changed ids.NodeID) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"NodeID", "Weight", "Share"})
+	table.SetRowLine(true)
+	for _, share := range shares {
+		weightStr := strconv.FormatUint(share.Weight, 10)
+		if share.NodeID == changed {
+			weightStr += " (new)"
+		}
+		table.Append([]string{
+			share.NodeID.String(),
+			weightStr,
+			fmt.Sprintf("%.2f%%", share.Share),
+		})
+	}
+	table.Render()
+}
+
+// waitForStakeEnd blocks until [nodeID] is not a validator of [subnetID] anymore,
+// which is expected to happen at [end]
+func waitForStakeEnd(subnetID ids.ID, nodeID ids.NodeID, network models.Network, end time.Time) error {
+	if wait := time.Until(end); wait > 0 {
+		ux.Logger.PrintToUser("Waiting for the stake of %s to end at %s...", nodeID, end.Format(constants.TimeParseLayout))
+		time.Sleep(wait)
+	}
+	for {
+		isValidator, err := subnet.IsSubnetValidator(subnetID, nodeID, network)
+		if err != nil {
+			return err
+		}
+		if !isValidator {
+			return nil
+		}
+		time.Sleep(stakeEndPollInterval)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnetcmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDurationWithDays(t *testing.T) {
+	require := require.New(t)
+
+	d, err := parseDurationWithDays("14d")
+	require.NoError(err)
+	require.Equal(14*24*time.Hour, d)
+	d, err = parseDurationWithDays("36h")
+	require.NoError(err)
+	require.Equal(36*time.Hour, d)
+	_, err = parseDurationWithDays("-1d")
+	require.Error(err)
+	_, err = parseDurationWithDays("soon")
+	require.Error(err)
+}
+
+func TestRenewals(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now().Truncate(time.Second)
+	unix := func(t time.Time) uint64 {
+		return uint64(t.Unix())
+	}
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+	nodeID3 := ids.GenerateTestNodeID()
+	nodeID4 := ids.GenerateTestNodeID()
+	validators := []platformvm.ClientPermissionlessValidator{
+		{ClientStaker: platformvm.ClientStaker{NodeID: nodeID1, Weight: 20, EndTime: unix(now.Add(10 * 24 * time.Hour))}},
+		{ClientStaker: platformvm.ClientStaker{NodeID: nodeID2, Weight: 30, EndTime: unix(now.Add(2 * 24 * time.Hour))}},
+		{ClientStaker: platformvm.ClientStaker{NodeID: nodeID3, Weight: 40, EndTime: unix(now.Add(3 * 24 * time.Hour))}},
+		{ClientStaker: platformvm.ClientStaker{NodeID: nodeID4, Weight: 50, EndTime: unix(now.Add(30 * 24 * time.Hour))}},
+	}
+
+	expiring := getExpiringValidators(validators, now.Add(14*24*time.Hour))
+	require.Equal(validators[:3], expiring)
+
+	primaryEnd := now.Add(35 * 24 * time.Hour)
+	primaryValidators := map[ids.NodeID]time.Time{
+		nodeID1: primaryEnd,
+		nodeID2: primaryEnd,
+		// primary validation ends together with the subnet one
+		nodeID3: time.Unix(int64(validators[2].EndTime), 0),
+	}
+	renewals, problems := planRenewals(expiring, primaryValidators, 30*24*time.Hour, constants.StakingStartLeadTime)
+	require.Len(problems, 1)
+	require.Contains(problems[0], nodeID3.String())
+	// sorted by start time, and capped by the primary network end
+	start2 := now.Add(2 * 24 * time.Hour).Add(constants.StakingStartLeadTime)
+	start1 := now.Add(10 * 24 * time.Hour).Add(constants.StakingStartLeadTime)
+	require.Equal([]batchValidator{
+		{NodeID: nodeID2, Weight: 30, Start: start2, Duration: 30 * 24 * time.Hour},
+		{NodeID: nodeID1, Weight: 20, Start: start1, Duration: primaryEnd.Sub(start1)},
+	}, renewals)
+
+	// without a period, renewals last until the primary network validation ends
+	renewals, _ = planRenewals(expiring[1:2], primaryValidators, 0, constants.StakingStartLeadTime)
+	require.Equal(primaryEnd, renewals[0].Start.Add(renewals[0].Duration))
+}
+
+func TestWeightDistribution(t *testing.T) {
+	require := require.New(t)
+
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+	nodeID3 := ids.GenerateTestNodeID()
+	validators := []platformvm.ClientPermissionlessValidator{
+		{ClientStaker: platformvm.ClientStaker{NodeID: nodeID1, Weight: 20}},
+		{ClientStaker: platformvm.ClientStaker{NodeID: nodeID2, Weight: 20}},
+		{ClientStaker: platformvm.ClientStaker{NodeID: nodeID3, Weight: 20}},
+	}
+
+	shares := getWeightDistribution(validators, nodeID2, 60)
+	require.Equal([]validatorShare{
+		{NodeID: nodeID2, Weight: 60, Share: 60},
+		{NodeID: nodeID1, Weight: 20, Share: 20},
+		{NodeID: nodeID3, Weight: 20, Share: 20},
+	}, shares)
+	require.Equal(shares[:1], getOverweightValidators(shares, 33))
+	require.Empty(getOverweightValidators(shares, 60))
+
+	// a node being added back after its removal
+	shares = getWeightDistribution(validators[:2], nodeID3, 40)
+	require.Equal(validatorShare{NodeID: nodeID3, Weight: 40, Share: 50}, shares[0])
+}
+
+func TestPendingRenewals(t *testing.T) {
+	require := require.New(t)
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, nil, nil)
+	defer func() {
+		app = nil
+	}()
+	require.NoError(app.CreateSidecar(&models.Sidecar{Name: "subnet", Subnet: "subnet", VM: models.SubnetEvm}))
+
+	renewals, err := loadPendingRenewals("subnet")
+	require.NoError(err)
+	require.Empty(renewals)
+
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+	start := time.Unix(time.Now().Unix(), 0)
+	pending := []batchValidator{
+		{NodeID: nodeID1, Weight: 20, Start: start, Duration: time.Hour},
+		{NodeID: nodeID2, Weight: 30, Start: start.Add(time.Hour), Duration: time.Hour},
+	}
+	renewals[models.Fuji.String()] = pending
+	require.NoError(savePendingRenewals("subnet", renewals))
+	loaded, err := loadPendingRenewals("subnet")
+	require.NoError(err)
+	require.Len(loaded[models.Fuji.String()], 2)
+	require.True(loaded[models.Fuji.String()][0].Start.Equal(start))
+	require.True(hasPendingRenewal(loaded[models.Fuji.String()], nodeID2))
+	require.False(hasPendingRenewal(loaded[models.Mainnet.String()], nodeID2))
+	require.Equal(pending[1:], pendingAfter(pending, nodeID2))
+}
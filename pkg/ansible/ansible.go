@@ -155,6 +155,26 @@ func RunAnsiblePlaybookSubnetSyncStatus(ansibleDir, subnetSyncPath, blockchainID
 	return cmd.Run()
 }
 
+// RunAnsiblePlaybookUpgradeSubnet installs the upgrade file at upgradeFilePath for blockchainID on the
+// cloud servers, restarting them one at a time. Each restarted server must become healthy before the
+// next one is restarted, and its chain config is saved into chainConfigJSONDir
+func RunAnsiblePlaybookUpgradeSubnet(ansibleDir, inventoryPath, upgradeFilePath, blockchainID, chainConfigDir, chainConfigJSONDir string) error {
+	playbookInputs := "upgradeFilePath=" + upgradeFilePath + " blockchainID=" + blockchainID + " chainConfigDir=" + chainConfigDir + " chainConfigJsonDir=" + chainConfigJSONDir
+	cmd := exec.Command(constants.AnsiblePlaybook, constants.UpgradeSubnetPlaybook, constants.AnsibleInventoryFlag, inventoryPath, constants.AnsibleExtraVarsFlag, playbookInputs, constants.AnsibleExtraArgsIdentitiesOnlyFlag) //nolint:gosec
+	cmd.Dir = ansibleDir
+	utils.SetupRealtimeCLIOutput(cmd)
+	return cmd.Run()
+}
+
+// RunAnsiblePlaybookGetChainConfig saves the chain config of blockchainID reported by each cloud
+// server into chainConfigJSONDir, or the error of the request
+func RunAnsiblePlaybookGetChainConfig(ansibleDir, inventoryPath, blockchainID, chainConfigJSONDir string) error {
+	playbookInputs := "blockchainID=" + blockchainID + " chainConfigJsonDir=" + chainConfigJSONDir
+	cmd := exec.Command(constants.AnsiblePlaybook, constants.GetChainConfigPlaybook, constants.AnsibleInventoryFlag, inventoryPath, constants.AnsibleExtraVarsFlag, playbookInputs, constants.AnsibleExtraArgsIdentitiesOnlyFlag) //nolint:gosec
+	cmd.Dir = ansibleDir
+	return cmd.Run()
+}
+
 func CheckIsInstalled() error {
 	if err := exec.Command(constants.AnsiblePlaybook).Run(); errors.Is(err, exec.ErrNotFound) { //nolint:gosec
 		ux.Logger.PrintToUser("Ansible tool is not available. It is a necessary dependency for CLI to set up a remote node.")
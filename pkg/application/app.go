@@ -5,6 +5,7 @@ package application
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/subnet-evm/core"
+	"golang.org/x/exp/slices"
 )
 
 type Avalanche struct {
@@ -104,6 +106,14 @@ func (app *Avalanche) GetGenesisMainnetPath(subnetName string) string {
 	return filepath.Join(app.GetSubnetDir(), subnetName, constants.GenesisMainnetFileName)
 }
 
+func (app *Avalanche) GetGenesisSchemaPath(subnetName string) string {
+	return filepath.Join(app.GetSubnetDir(), subnetName, constants.GenesisSchemaFileName)
+}
+
+func (app *Avalanche) GetDescribeHookPath(subnetName string) string {
+	return filepath.Join(app.GetSubnetDir(), subnetName, constants.DescribeHookFileName)
+}
+
 func (app *Avalanche) GetSidecarPath(subnetName string) string {
 	return filepath.Join(app.GetSubnetDir(), subnetName, constants.SidecarFileName)
 }
@@ -187,6 +197,10 @@ func (app *Avalanche) GetClusterConfigPath() string {
 	return filepath.Join(app.GetNodesDir(), constants.ClusterConfigFileName)
 }
 
+func (app *Avalanche) GetPendingRenewalsPath(subnetName string) string {
+	return filepath.Join(app.GetSubnetDir(), subnetName, constants.PendingRenewalsFileName)
+}
+
 func (app *Avalanche) GetElasticSubnetConfigPath(subnetName string) string {
 	return filepath.Join(app.GetSubnetDir(), subnetName, constants.ElasticSubnetConfigFileName)
 }
@@ -313,6 +327,34 @@ func (app *Avalanche) CopyVMBinary(inputFilename string, subnetName string) erro
 	return os.WriteFile(vmPath, vmBytes, constants.WriteReadReadPerms)
 }
 
+// CopySubnetDir copies all configuration files of [srcName] into the
+// directory of [dstName], except for the file names listed in [skipFiles]
+func (app *Avalanche) CopySubnetDir(srcName string, dstName string, skipFiles []string) error {
+	srcDir := filepath.Join(app.GetSubnetDir(), srcName)
+	dstDir := filepath.Join(app.GetSubnetDir(), dstName)
+	return filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		dstPath := filepath.Join(dstDir, relPath)
+		if d.IsDir() {
+			return os.MkdirAll(dstPath, constants.DefaultPerms755)
+		}
+		if slices.Contains(skipFiles, d.Name()) {
+			return nil
+		}
+		fileBytes, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dstPath, fileBytes, constants.WriteReadReadPerms)
+	})
+}
+
 func (app *Avalanche) CopyKeyFile(inputFilename string, keyName string) error {
 	keyBytes, err := os.ReadFile(inputFilename)
 	if err != nil {
@@ -463,23 +505,36 @@ func (app *Avalanche) UpdateSidecarPermissionlessValidator(
 	return nil
 }
 
-func (app *Avalanche) UpdateSidecarElasticSubnetPartialTx(
+// UpdateSidecarJournal persists [txID] as confirmed for [step] of the multi-tx
+// [operation] on [network], so that a rerun of the operation can skip it
+func (app *Avalanche) UpdateSidecarJournal(
 	sc *models.Sidecar,
 	network models.Network,
-	txName string,
+	operation string,
+	step string,
 	txID ids.ID,
 ) error {
-	if sc.ElasticSubnet == nil {
-		sc.ElasticSubnet = make(map[string]models.ElasticSubnet)
-	}
-	partialTxs := make(map[string]ids.ID)
-	if sc.ElasticSubnet[network.String()].Txs != nil {
-		partialTxs = sc.ElasticSubnet[network.String()].Txs
-	}
-	partialTxs[txName] = txID
-	sc.ElasticSubnet[network.String()] = models.ElasticSubnet{
-		Txs: partialTxs,
-	}
+	sc.SetJournalStep(network, operation, step, txID)
+	return app.UpdateSidecar(sc)
+}
+
+// ClearSidecarJournal removes the journal of the completed [operation] on [network]
+func (app *Avalanche) ClearSidecarJournal(
+	sc *models.Sidecar,
+	network models.Network,
+	operation string,
+) error {
+	sc.ClearJournal(network, operation)
+	return app.UpdateSidecar(sc)
+}
+
+func (app *Avalanche) ClearSidecarJournalStep(
+	sc *models.Sidecar,
+	network models.Network,
+	operation string,
+	step string,
+) error {
+	sc.ClearJournalStep(network, operation, step)
 	return app.UpdateSidecar(sc)
 }
 
@@ -510,6 +565,46 @@ func (app *Avalanche) GetSidecarNames() ([]string, error) {
 	return names, nil
 }
 
+// GetChainsInSubnet returns the names of all blockchain configurations that
+// belong to [subnetName]. The subnet's own chain, if present, comes first.
+func (app *Avalanche) GetChainsInSubnet(subnetName string) ([]string, error) {
+	subnets, err := os.ReadDir(app.GetSubnetDir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseDir: %w", err)
+	}
+
+	chains := []string{}
+
+	for _, s := range subnets {
+		if !s.IsDir() {
+			continue
+		}
+		sidecarFile := filepath.Join(app.GetSubnetDir(), s.Name(), constants.SidecarFileName)
+		if _, err := os.Stat(sidecarFile); err == nil {
+			// read in sidecar file
+			jsonBytes, err := os.ReadFile(sidecarFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed reading file %s: %w", sidecarFile, err)
+			}
+
+			var sc models.Sidecar
+			err = json.Unmarshal(jsonBytes, &sc)
+			if err != nil {
+				return nil, fmt.Errorf("failed unmarshaling file %s: %w", sidecarFile, err)
+			}
+			if sc.Subnet != subnetName {
+				continue
+			}
+			if sc.Name == subnetName {
+				chains = append([]string{sc.Name}, chains...)
+			} else {
+				chains = append(chains, sc.Name)
+			}
+		}
+	}
+	return chains, nil
+}
+
 func (*Avalanche) readFile(path string) ([]byte, error) {
 	if err := os.MkdirAll(filepath.Dir(path), constants.DefaultPerms755); err != nil {
 		return nil, err
@@ -275,6 +275,28 @@ func Test_genesisExists(t *testing.T) {
 	require.NoError(err)
 }
 
+func Test_getChainsInSubnet(t *testing.T) {
+	require := require.New(t)
+	ap := newTestApp(t)
+
+	sidecars := []*models.Sidecar{
+		{Name: "chainB", Subnet: subnetName1, VM: models.SubnetEvm},
+		{Name: subnetName1, Subnet: subnetName1, VM: models.SubnetEvm, Chains: []string{"chainB"}},
+		{Name: subnetName2, Subnet: subnetName2, VM: models.SubnetEvm},
+	}
+	for _, sc := range sidecars {
+		require.NoError(ap.CreateSidecar(sc))
+	}
+
+	chains, err := ap.GetChainsInSubnet(subnetName1)
+	require.NoError(err)
+	require.Equal([]string{subnetName1, "chainB"}, chains)
+
+	chains, err = ap.GetChainsInSubnet(subnetName2)
+	require.NoError(err)
+	require.Equal([]string{subnetName2}, chains)
+}
+
 func newTestApp(t *testing.T) *Avalanche {
 	tempDir := t.TempDir()
 	return &Avalanche{
@@ -0,0 +1,350 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package bundle reads and writes self-contained subnet export bundles: tar.gz
+// archives that contain a versioned manifest, all configuration files of a subnet
+// and, optionally, its custom VM binary and per-network deployment data.
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// Version of the bundle format. Bump it on incompatible changes
+	Version = "1"
+
+	ManifestFileName = "manifest.json"
+	subnetFilesDir   = "subnet"
+	vmBinaryPath     = "vm/vm"
+)
+
+var (
+	ErrChecksumMismatch   = errors.New("bundle checksum mismatch")
+	ErrUnsupportedVersion = errors.New("unsupported bundle version")
+	ErrSubnetExists       = errors.New("subnet already exists")
+
+	// files that only make sense for a deployed subnet
+	deploymentFiles = []string{
+		constants.ElasticSubnetConfigFileName,
+		constants.PendingRenewalsFileName,
+		constants.UpgradeBytesFileName + constants.UpgradeBytesLockExtension,
+	}
+)
+
+// File is a file of the bundle with its sha256 checksum
+type File struct {
+	Path   string
+	SHA256 string
+}
+
+// Manifest describes the content of a bundle
+type Manifest struct {
+	Version             string
+	SubnetName          string
+	VM                  models.VMType
+	CreatedAt           time.Time
+	IncludesVMBinary    bool
+	IncludesDeployments bool
+	Files               []File
+}
+
+// ExportOptions selects the optional content of a bundle
+type ExportOptions struct {
+	IncludeVMBinary    bool
+	IncludeDeployments bool
+}
+
+// IsBundle returns true if the file at [filePath] is a gzip archive, as bundles are
+func IsBundle(filePath string) (bool, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(f, header); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		return false, err
+	}
+	// gzip magic number
+	return header[0] == 0x1f && header[1] == 0x8b, nil
+}
+
+// Export writes the bundle of [subnetName] to [outputPath]
+func Export(app *application.Avalanche, subnetName string, outputPath string, opts ExportOptions) error {
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return err
+	}
+	// the additional chains live in their own dirs, and the imported sidecar
+	// would point at chains that don't exist
+	if len(sc.Chains) > 0 {
+		return fmt.Errorf("subnet %s has the additional chains %s, which can't be bundled", subnetName, strings.Join(sc.Chains, ", "))
+	}
+	if !opts.IncludeDeployments {
+		sc.Networks = nil
+		sc.ElasticSubnet = nil
+		sc.Journals = nil
+	}
+	scBytes, err := json.MarshalIndent(sc, "", "    ")
+	if err != nil {
+		return err
+	}
+	files := map[string][]byte{
+		path.Join(subnetFilesDir, constants.SidecarFileName): scBytes,
+	}
+
+	subnetDir := filepath.Join(app.GetSubnetDir(), subnetName)
+	if err := filepath.WalkDir(subnetDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// a describe hook is run by subnet describe, so it is never shipped to
+		// another machine: it has to be configured locally
+		if d.IsDir() || d.Name() == constants.SidecarFileName || d.Name() == constants.DescribeHookFileName {
+			return nil
+		}
+		if !opts.IncludeDeployments && slices.Contains(deploymentFiles, d.Name()) {
+			return nil
+		}
+		relPath, err := filepath.Rel(subnetDir, filePath)
+		if err != nil {
+			return err
+		}
+		fileBytes, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		files[path.Join(subnetFilesDir, filepath.ToSlash(relPath))] = fileBytes
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	includesVMBinary := false
+	if opts.IncludeVMBinary && sc.VM == models.CustomVM {
+		vmBytes, err := os.ReadFile(app.GetCustomVMPath(subnetName))
+		if err != nil {
+			return fmt.Errorf("failed reading custom VM binary: %w", err)
+		}
+		files[vmBinaryPath] = vmBytes
+		includesVMBinary = true
+	}
+
+	manifest := Manifest{
+		Version:             Version,
+		SubnetName:          subnetName,
+		VM:                  sc.VM,
+		CreatedAt:           time.Now().UTC(),
+		IncludesVMBinary:    includesVMBinary,
+		IncludesDeployments: opts.IncludeDeployments,
+	}
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		manifest.Files = append(manifest.Files, File{Path: p, SHA256: checksum(files[p])})
+	}
+	manifestBytes, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	if err := writeTarFile(tarWriter, ManifestFileName, manifestBytes, constants.WriteReadReadPerms); err != nil {
+		return err
+	}
+	for _, p := range paths {
+		perms := int64(constants.WriteReadReadPerms)
+		if p == vmBinaryPath {
+			perms = constants.DefaultPerms755
+		}
+		if err := writeTarFile(tarWriter, p, files[p], perms); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(outputPath, buf.Bytes(), constants.WriteReadReadPerms)
+}
+
+// Read loads the bundle at [bundlePath], verifying its version and checksums.
+// Returns the manifest and the bundle files by path
+func Read(bundlePath string) (Manifest, map[string][]byte, error) {
+	bundleBytes, err := os.ReadFile(bundlePath)
+	if err != nil {
+		return Manifest{}, nil, err
+	}
+	gzipReader, err := gzip.NewReader(bytes.NewReader(bundleBytes))
+	if err != nil {
+		return Manifest{}, nil, fmt.Errorf("failed reading bundle: %w", err)
+	}
+	defer gzipReader.Close()
+	tarReader := tar.NewReader(gzipReader)
+
+	files := map[string][]byte{}
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Manifest{}, nil, fmt.Errorf("failed reading bundle: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		fileBytes, err := io.ReadAll(tarReader)
+		if err != nil {
+			return Manifest{}, nil, err
+		}
+		files[header.Name] = fileBytes
+	}
+
+	manifestBytes, ok := files[ManifestFileName]
+	if !ok {
+		return Manifest{}, nil, errors.New("bundle is malformed: missing manifest")
+	}
+	delete(files, ManifestFileName)
+	var manifest Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return Manifest{}, nil, fmt.Errorf("bundle is malformed: %w", err)
+	}
+	if manifest.Version != Version {
+		return Manifest{}, nil, fmt.Errorf("%w %q, expected %q", ErrUnsupportedVersion, manifest.Version, Version)
+	}
+	if manifest.SubnetName == "" {
+		return Manifest{}, nil, errors.New("bundle is malformed: missing subnet name")
+	}
+	// the name is used as a path, it must not be able to escape the subnets dir
+	if err := utils.ValidateSubnetName(manifest.SubnetName); err != nil {
+		return Manifest{}, nil, fmt.Errorf("bundle is malformed: invalid subnet name %q: %w", manifest.SubnetName, err)
+	}
+	if len(manifest.Files) != len(files) {
+		return Manifest{}, nil, errors.New("bundle is malformed: manifest does not match the bundle content")
+	}
+	for _, f := range manifest.Files {
+		fileBytes, ok := files[f.Path]
+		if !ok {
+			return Manifest{}, nil, fmt.Errorf("bundle is malformed: missing file %s", f.Path)
+		}
+		if checksum(fileBytes) != f.SHA256 {
+			return Manifest{}, nil, fmt.Errorf("%w for %s", ErrChecksumMismatch, f.Path)
+		}
+	}
+	scBytes, ok := files[path.Join(subnetFilesDir, constants.SidecarFileName)]
+	if !ok {
+		return Manifest{}, nil, errors.New("bundle is malformed: missing sidecar")
+	}
+	var sc models.Sidecar
+	if err := json.Unmarshal(scBytes, &sc); err != nil {
+		return Manifest{}, nil, fmt.Errorf("bundle is malformed: %w", err)
+	}
+	if sc.Name != manifest.SubnetName {
+		return Manifest{}, nil, fmt.Errorf("bundle is malformed: sidecar of %q for subnet %q", sc.Name, manifest.SubnetName)
+	}
+	return manifest, files, nil
+}
+
+// Import reads the bundle at [bundlePath] and installs its subnet. Returns the imported manifest
+func Import(app *application.Avalanche, bundlePath string, overwrite bool) (Manifest, error) {
+	manifest, files, err := Read(bundlePath)
+	if err != nil {
+		return Manifest{}, err
+	}
+	subnetName := manifest.SubnetName
+	if app.SidecarExists(subnetName) && !overwrite {
+		return Manifest{}, ErrSubnetExists
+	}
+
+	for p := range files {
+		// never install an executable run by subnet describe from a bundle
+		if path.Base(p) == constants.DescribeHookFileName {
+			return Manifest{}, fmt.Errorf("bundle is malformed: unexpected describe hook %s", p)
+		}
+	}
+
+	// files of a previous import are not mixed with the bundle ones
+	subnetDir := filepath.Join(app.GetSubnetDir(), subnetName)
+	if err := os.RemoveAll(subnetDir); err != nil {
+		return Manifest{}, err
+	}
+	for p, fileBytes := range files {
+		if p == vmBinaryPath {
+			if err := os.MkdirAll(app.GetCustomVMDir(), constants.DefaultPerms755); err != nil {
+				return Manifest{}, err
+			}
+			if err := os.WriteFile(app.GetCustomVMPath(subnetName), fileBytes, constants.DefaultPerms755); err != nil {
+				return Manifest{}, err
+			}
+			continue
+		}
+		if !strings.HasPrefix(p, subnetFilesDir+"/") {
+			return Manifest{}, fmt.Errorf("bundle is malformed: unexpected file %s", p)
+		}
+		relPath := strings.TrimPrefix(p, subnetFilesDir+"/")
+		// Sanitize archive file pathing from "G305: Zip Slip vulnerability"
+		dstPath := filepath.Join(subnetDir, filepath.FromSlash(relPath))
+		if !strings.HasPrefix(dstPath, filepath.Clean(subnetDir)+string(os.PathSeparator)) {
+			return Manifest{}, fmt.Errorf("bundle is malformed: tainted file path %s", p)
+		}
+		if err := os.MkdirAll(filepath.Dir(dstPath), constants.DefaultPerms755); err != nil {
+			return Manifest{}, err
+		}
+		if err := os.WriteFile(dstPath, fileBytes, constants.WriteReadReadPerms); err != nil {
+			return Manifest{}, err
+		}
+	}
+	return manifest, nil
+}
+
+func writeTarFile(tarWriter *tar.Writer, name string, fileBytes []byte, perms int64) error {
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     perms,
+		Size:     int64(len(fileBytes)),
+		Typeflag: tar.TypeReg,
+		ModTime:  time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err := tarWriter.Write(fileBytes)
+	return err
+}
+
+func checksum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
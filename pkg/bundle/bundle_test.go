@@ -0,0 +1,209 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+const testSubnet = "testSubnet"
+
+func newTestApp(t *testing.T) *application.Avalanche {
+	app := application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, nil, nil)
+	return app
+}
+
+func setupTestSubnet(t *testing.T, app *application.Avalanche) {
+	require := require.New(t)
+	sc := &models.Sidecar{Name: testSubnet, Subnet: testSubnet, VM: models.CustomVM}
+	require.NoError(app.CreateSidecar(sc))
+	require.NoError(app.UpdateSidecarNetworks(sc, models.Fuji, ids.GenerateTestID(), ids.GenerateTestID()))
+	require.NoError(app.WriteGenesisFile(testSubnet, []byte("genesis")))
+	require.NoError(app.WriteUpgradeFile(testSubnet, []byte("upgrade")))
+	require.NoError(app.WriteLockUpgradeFile(testSubnet, []byte("lock")))
+	chainConfigPath := filepath.Join(app.GetSubnetDir(), testSubnet, constants.ChainConfigFileName)
+	require.NoError(os.WriteFile(chainConfigPath, []byte("{}"), constants.WriteReadReadPerms))
+	require.NoError(os.MkdirAll(app.GetCustomVMDir(), constants.DefaultPerms755))
+	require.NoError(os.WriteFile(app.GetCustomVMPath(testSubnet), []byte("vm"), constants.DefaultPerms755))
+}
+
+func TestExportImport(t *testing.T) {
+	require := require.New(t)
+	srcApp := newTestApp(t)
+	setupTestSubnet(t, srcApp)
+
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	require.NoError(Export(srcApp, testSubnet, bundlePath, ExportOptions{IncludeVMBinary: true, IncludeDeployments: true}))
+	isBundle, err := IsBundle(bundlePath)
+	require.NoError(err)
+	require.True(isBundle)
+
+	dstApp := newTestApp(t)
+	manifest, err := Import(dstApp, bundlePath, false)
+	require.NoError(err)
+	require.Equal(testSubnet, manifest.SubnetName)
+	require.True(manifest.IncludesVMBinary)
+
+	sc, err := dstApp.LoadSidecar(testSubnet)
+	require.NoError(err)
+	require.NotEmpty(sc.Networks)
+	genesis, err := os.ReadFile(dstApp.GetGenesisPath(testSubnet))
+	require.NoError(err)
+	require.Equal([]byte("genesis"), genesis)
+	lock, err := dstApp.ReadLockUpgradeFile(testSubnet)
+	require.NoError(err)
+	require.Equal([]byte("lock"), lock)
+	require.FileExists(filepath.Join(dstApp.GetSubnetDir(), testSubnet, constants.ChainConfigFileName))
+	vmBytes, err := os.ReadFile(dstApp.GetCustomVMPath(testSubnet))
+	require.NoError(err)
+	require.Equal([]byte("vm"), vmBytes)
+
+	// already exists
+	_, err = Import(dstApp, bundlePath, false)
+	require.ErrorIs(err, ErrSubnetExists)
+	// overwriting drops the files of the previous import
+	stalePath := filepath.Join(dstApp.GetSubnetDir(), testSubnet, "stale.json")
+	require.NoError(os.WriteFile(stalePath, []byte("{}"), constants.WriteReadReadPerms))
+	_, err = Import(dstApp, bundlePath, true)
+	require.NoError(err)
+	require.NoFileExists(stalePath)
+	require.FileExists(dstApp.GetGenesisPath(testSubnet))
+}
+
+func TestExportWithChains(t *testing.T) {
+	require := require.New(t)
+	app := newTestApp(t)
+	setupTestSubnet(t, app)
+	sc, err := app.LoadSidecar(testSubnet)
+	require.NoError(err)
+	sc.Chains = []string{"otherChain"}
+	require.NoError(app.UpdateSidecar(&sc))
+
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	require.ErrorContains(Export(app, testSubnet, bundlePath, ExportOptions{}), "can't be bundled")
+	require.NoFileExists(bundlePath)
+}
+
+func TestImportSidecarNameMismatch(t *testing.T) {
+	require := require.New(t)
+	app := newTestApp(t)
+	bundlePath := writeTestBundle(t, Manifest{Version: Version, SubnetName: testSubnet}, map[string][]byte{
+		"subnet/" + constants.SidecarFileName: testSidecarBytes(t, "otherSubnet"),
+		"subnet/" + constants.GenesisFileName: []byte("genesis"),
+	})
+	_, err := Import(app, bundlePath, false)
+	require.ErrorContains(err, "bundle is malformed")
+	require.NoFileExists(app.GetGenesisPath(testSubnet))
+}
+
+func TestExportWithoutDeploymentsAndBinary(t *testing.T) {
+	require := require.New(t)
+	srcApp := newTestApp(t)
+	setupTestSubnet(t, srcApp)
+
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	require.NoError(Export(srcApp, testSubnet, bundlePath, ExportOptions{}))
+
+	manifest, files, err := Read(bundlePath)
+	require.NoError(err)
+	require.False(manifest.IncludesVMBinary)
+	require.False(manifest.IncludesDeployments)
+	require.NotContains(files, vmBinaryPath)
+	require.NotContains(files, "subnet/"+constants.UpgradeBytesFileName+constants.UpgradeBytesLockExtension)
+	require.Contains(files, "subnet/"+constants.UpgradeBytesFileName)
+
+	dstApp := newTestApp(t)
+	_, err = Import(dstApp, bundlePath, false)
+	require.NoError(err)
+	sc, err := dstApp.LoadSidecar(testSubnet)
+	require.NoError(err)
+	require.Empty(sc.Networks)
+}
+
+func TestIsBundle(t *testing.T) {
+	require := require.New(t)
+	jsonPath := filepath.Join(t.TempDir(), "export.json")
+	require.NoError(os.WriteFile(jsonPath, []byte("{}"), constants.WriteReadReadPerms))
+	isBundle, err := IsBundle(jsonPath)
+	require.NoError(err)
+	require.False(isBundle)
+}
+
+func testSidecarBytes(t *testing.T, name string) []byte {
+	scBytes, err := json.Marshal(models.Sidecar{Name: name, Subnet: name, VM: models.CustomVM})
+	require.NoError(t, err)
+	return scBytes
+}
+
+func writeTestBundle(t *testing.T, manifest Manifest, files map[string][]byte) string {
+	require := require.New(t)
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for p, fileBytes := range files {
+		manifest.Files = append(manifest.Files, File{Path: p, SHA256: checksum(fileBytes)})
+		require.NoError(writeTarFile(tarWriter, p, fileBytes, constants.WriteReadReadPerms))
+	}
+	manifestBytes, err := json.Marshal(manifest)
+	require.NoError(err)
+	require.NoError(writeTarFile(tarWriter, ManifestFileName, manifestBytes, constants.WriteReadReadPerms))
+	require.NoError(tarWriter.Close())
+	require.NoError(gzipWriter.Close())
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	require.NoError(os.WriteFile(bundlePath, buf.Bytes(), constants.WriteReadReadPerms))
+	return bundlePath
+}
+
+func TestImportInvalidSubnetName(t *testing.T) {
+	require := require.New(t)
+	for _, subnetName := range []string{"../../x", "a/b", `a\b`, "..", "bad-name"} {
+		app := newTestApp(t)
+		bundlePath := writeTestBundle(t, Manifest{Version: Version, SubnetName: subnetName}, map[string][]byte{
+			"subnet/" + constants.GenesisFileName: []byte("genesis"),
+			vmBinaryPath:                          []byte("vm"),
+		})
+		_, err := Import(app, bundlePath, false)
+		require.ErrorContains(err, "invalid subnet name", subnetName)
+		require.NoFileExists(filepath.Join(app.GetSubnetDir(), subnetName, constants.GenesisFileName))
+		require.NoFileExists(app.GetCustomVMPath(subnetName))
+	}
+}
+
+func TestDescribeHookNotBundled(t *testing.T) {
+	require := require.New(t)
+	srcApp := newTestApp(t)
+	setupTestSubnet(t, srcApp)
+	require.NoError(os.WriteFile(srcApp.GetDescribeHookPath(testSubnet), []byte("#!/bin/sh\n"), constants.DefaultPerms755))
+
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	require.NoError(Export(srcApp, testSubnet, bundlePath, ExportOptions{}))
+	_, files, err := Read(bundlePath)
+	require.NoError(err)
+	require.NotContains(files, "subnet/"+constants.DescribeHookFileName)
+
+	// a crafted bundle can't install one either
+	dstApp := newTestApp(t)
+	bundlePath = writeTestBundle(t, Manifest{Version: Version, SubnetName: testSubnet}, map[string][]byte{
+		"subnet/" + constants.SidecarFileName:      testSidecarBytes(t, testSubnet),
+		"subnet/" + constants.GenesisFileName:      []byte("genesis"),
+		"subnet/" + constants.DescribeHookFileName: []byte("#!/bin/sh\n"),
+	})
+	_, err = Import(dstApp, bundlePath, false)
+	require.ErrorContains(err, "unexpected describe hook")
+	require.NoFileExists(dstApp.GetDescribeHookPath(testSubnet))
+	require.NoFileExists(dstApp.GetGenesisPath(testSubnet))
+}
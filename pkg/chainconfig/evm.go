@@ -0,0 +1,109 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package chainconfig
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/plugin/evm"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// EvmSchema describes the chain config of subnet-evm
+var EvmSchema = Schema{
+	Name:   "subnet-evm chain config",
+	Fields: evmFields,
+	Verify: verifyEvmConfig,
+}
+
+var (
+	nonNegative = Field{Type: IntType, Min: bound(0)}
+	positive    = Field{Type: IntType, Min: bound(1)}
+	duration    = Field{Type: DurationType, Min: bound(0)}
+)
+
+// evmFieldOverrides refines the fields derived from [evm.Config] with the
+// constraints its Go types can't express
+var evmFieldOverrides = map[string]Field{
+	"eth-apis": {
+		Type: StringListType,
+		Values: []string{
+			"eth", "eth-filter", "admin", "debug", "net", "web3", "internal-eth", "internal-blockchain",
+			"internal-transaction", "internal-tx-pool", "internal-debug", "internal-account",
+			"internal-personal", "debug-tracer", "debug-file-tracer", "debug-handler",
+			// legacy names, still accepted by subnet-evm
+			"internal-public-eth", "internal-public-blockchain", "internal-public-transaction-pool",
+			"internal-public-tx-pool", "internal-public-debug", "internal-private-debug",
+			"internal-public-account", "internal-private-personal", "public-eth", "public-eth-filter",
+			"private-admin", "public-debug", "private-debug",
+		},
+	},
+	"rpc-tx-fee-cap":      {Type: FloatType, Min: bound(0)},
+	"log-level":           {Type: StringType, Values: []string{"trace", "trce", "debug", "dbug", "info", "warn", "error", "eror", "crit"}},
+	"feeRecipient":        {Type: AddressType},
+	"accepted-cache-size": positive,
+}
+
+var evmFields = getEvmFields()
+
+// getEvmFields returns the fields of the json tags of [evm.Config], so that the
+// schema follows the subnet-evm version in use
+func getEvmFields() map[string]Field {
+	fields := map[string]Field{}
+	configType := reflect.TypeOf(evm.Config{})
+	for i := 0; i < configType.NumField(); i++ {
+		structField := configType.Field(i)
+		key, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		if field, ok := evmFieldOverrides[key]; ok {
+			fields[key] = field
+			continue
+		}
+		fields[key] = getEvmField(structField.Type)
+	}
+	return fields
+}
+
+// getEvmField returns the field of a value of Go type [t]. Types not known here,
+// which a subnet-evm upgrade may add, accept any value and are left to [verifyEvmConfig]
+func getEvmField(t reflect.Type) Field {
+	switch t {
+	case reflect.TypeOf(evm.Duration{}):
+		return duration
+	case reflect.TypeOf([]common.Hash{}):
+		return Field{Type: HashListType}
+	case reflect.TypeOf([]common.Address{}):
+		return Field{Type: AddressListType}
+	case reflect.TypeOf([]string{}):
+		return Field{Type: StringListType}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return getEvmField(t.Elem())
+	case reflect.Bool:
+		return Field{Type: BoolType}
+	case reflect.String:
+		return Field{Type: StringType}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return nonNegative
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return Field{Type: IntType, Min: bound(0), Max: bound(float64(uint64(1)<<t.Bits() - 1))}
+	case reflect.Float32, reflect.Float64:
+		return Field{Type: FloatType}
+	}
+	return Field{Type: AnyType}
+}
+
+// verifyEvmConfig checks the combination of settings, the way subnet-evm does on startup
+func verifyEvmConfig(configBytes []byte) error {
+	var config evm.Config
+	config.SetDefaults()
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return err
+	}
+	return config.Validate()
+}
@@ -0,0 +1,360 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package chainconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"golang.org/x/exp/slices"
+)
+
+// ValueType is the type of a config value
+type ValueType string
+
+const (
+	BoolType    ValueType = "bool"
+	IntType     ValueType = "integer"
+	FloatType   ValueType = "number"
+	StringType  ValueType = "string"
+	AddressType ValueType = "address"
+	// DurationType values are duration strings, such as "1m30s", or nanoseconds
+	DurationType ValueType = "duration"
+	// NanosecondsType values are nanoseconds, which can be set from duration strings
+	NanosecondsType ValueType = "nanoseconds"
+	StringListType  ValueType = "string list"
+	AddressListType ValueType = "address list"
+	HashListType    ValueType = "hash list"
+	NodeIDListType  ValueType = "node ID list"
+	// AnyType values are any JSON value, left for the node to check
+	AnyType ValueType = "any"
+)
+
+var ErrUnknownKey = errors.New("unknown key")
+
+// Field describes a config value. Min and Max bound numbers and durations,
+// and Values lists the allowed strings
+type Field struct {
+	Type   ValueType
+	Min    *float64
+	Max    *float64
+	Values []string
+}
+
+// Schema describes the keys of a config file. Keys of nested objects are
+// joined with dots
+type Schema struct {
+	Name   string
+	Fields map[string]Field
+	// Verify checks the config as a whole, the same way the node does
+	Verify func(configBytes []byte) error
+}
+
+func bound(v float64) *float64 {
+	return &v
+}
+
+// Keys returns the sorted keys of the schema
+func (s Schema) Keys() []string {
+	keys := make([]string, 0, len(s.Fields))
+	for key := range s.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Decode decodes a config file, keeping numbers exact
+func Decode(configBytes []byte) (map[string]interface{}, error) {
+	config := map[string]interface{}{}
+	if len(bytes.TrimSpace(configBytes)) == 0 {
+		return config, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(configBytes))
+	decoder.UseNumber()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return config, nil
+}
+
+// Encode encodes a config file, with sorted keys
+func Encode(config map[string]interface{}) ([]byte, error) {
+	return json.MarshalIndent(config, "", "  ")
+}
+
+// GetValue returns the value of the dotted [key] of [config]
+func GetValue(config map[string]interface{}, key string) (interface{}, bool) {
+	parts := strings.Split(key, ".")
+	current := config
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	value, ok := current[parts[len(parts)-1]]
+	return value, ok
+}
+
+// SetValue sets the dotted [key] of [config] to [value], creating the nested
+// objects as needed
+func SetValue(config map[string]interface{}, key string, value interface{}) error {
+	parts := strings.Split(key, ".")
+	current := config
+	for i, part := range parts[:len(parts)-1] {
+		next, ok := current[part]
+		if !ok {
+			next = map[string]interface{}{}
+			current[part] = next
+		}
+		nextMap, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s is not an object", strings.Join(parts[:i+1], "."))
+		}
+		current = nextMap
+	}
+	current[parts[len(parts)-1]] = value
+	return nil
+}
+
+// ParseValue converts the command line [value] of [key] into its config value.
+// ErrUnknownKey is returned for keys not in the schema
+func (s Schema) ParseValue(key, value string) (interface{}, error) {
+	field, ok := s.Fields[key]
+	if !ok {
+		return nil, fmt.Errorf("%w %q for %s", ErrUnknownKey, key, s.Name)
+	}
+	var parsed interface{}
+	switch field.Type {
+	case BoolType:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: expected true or false, got %q", key, value)
+		}
+		parsed = b
+	case IntType, FloatType:
+		parsed = json.Number(value)
+	case NanosecondsType:
+		if d, err := time.ParseDuration(value); err == nil {
+			parsed = json.Number(strconv.FormatInt(int64(d), 10))
+		} else {
+			parsed = json.Number(value)
+		}
+	case StringType, AddressType, DurationType:
+		parsed = value
+	case StringListType, AddressListType, HashListType, NodeIDListType:
+		list := []interface{}{}
+		for _, element := range strings.Split(value, ",") {
+			if element = strings.TrimSpace(element); element != "" {
+				list = append(list, element)
+			}
+		}
+		parsed = list
+	case AnyType:
+		parsed = ParseRawValue(value)
+	default:
+		return nil, fmt.Errorf("%s: unsupported type %s", key, field.Type)
+	}
+	if err := checkValue(field, parsed); err != nil {
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+	return parsed, nil
+}
+
+// ParseRawValue converts the command line [value] of a key that is not in the
+// schema, as JSON if it is valid JSON, or as a string otherwise
+func ParseRawValue(value string) interface{} {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	var parsed interface{}
+	if err := decoder.Decode(&parsed); err != nil || decoder.More() {
+		return value
+	}
+	return parsed
+}
+
+// Validate checks the types and ranges of the values of [config]. Unknown keys
+// are returned as warnings, as the node ignores them
+func (s Schema) Validate(config map[string]interface{}) ([]string, error) {
+	warnings := []string{}
+	problems := []string{}
+	s.validateObject(config, "", &warnings, &problems)
+	if len(problems) > 0 {
+		return warnings, fmt.Errorf("invalid %s:\n  %s", s.Name, strings.Join(problems, "\n  "))
+	}
+	if s.Verify != nil {
+		configBytes, err := json.Marshal(config)
+		if err != nil {
+			return warnings, err
+		}
+		if err := s.Verify(configBytes); err != nil {
+			return warnings, fmt.Errorf("invalid %s: %w", s.Name, err)
+		}
+	}
+	return warnings, nil
+}
+
+func (s Schema) validateObject(object map[string]interface{}, prefix string, warnings, problems *[]string) {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fullKey := prefix + key
+		value := object[key]
+		if field, ok := s.Fields[fullKey]; ok {
+			if err := checkValue(field, value); err != nil {
+				*problems = append(*problems, fmt.Sprintf("%s: %s", fullKey, err))
+			}
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok && s.hasPrefix(fullKey+".") {
+			s.validateObject(nested, fullKey+".", warnings, problems)
+			continue
+		}
+		warning := fmt.Sprintf("unknown key %q is ignored by the node", fullKey)
+		if suggestion := s.suggestKey(fullKey); suggestion != "" {
+			warning += fmt.Sprintf(", did you mean %q?", suggestion)
+		}
+		*warnings = append(*warnings, warning)
+	}
+}
+
+func (s Schema) hasPrefix(prefix string) bool {
+	for key := range s.Fields {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestKey returns the schema key that only differs from [key] by its case
+// or separators, if any
+func (s Schema) suggestKey(key string) string {
+	normalize := func(k string) string {
+		return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(k))
+	}
+	for _, schemaKey := range s.Keys() {
+		if normalize(schemaKey) == normalize(key) {
+			return schemaKey
+		}
+	}
+	return ""
+}
+
+func checkValue(field Field, value interface{}) error {
+	switch field.Type {
+	case BoolType:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected a bool, got %v", value)
+		}
+	case IntType, NanosecondsType:
+		n, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("expected an integer, got %v", value)
+		}
+		i, ok := new(big.Int).SetString(n.String(), 10)
+		if !ok {
+			return fmt.Errorf("expected an integer, got %s", n)
+		}
+		return checkRange(field, new(big.Float).SetInt(i))
+	case FloatType:
+		n, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("expected a number, got %v", value)
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return fmt.Errorf("expected a number, got %s", n)
+		}
+		return checkRange(field, big.NewFloat(f))
+	case StringType:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a string, got %v", value)
+		}
+		if len(field.Values) > 0 && !slices.Contains(field.Values, str) {
+			return fmt.Errorf("expected one of %s, got %q", strings.Join(field.Values, ", "), str)
+		}
+	case AddressType:
+		return checkElement(field.Type, value)
+	case DurationType:
+		switch v := value.(type) {
+		case string:
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("expected a duration such as 1m30s, got %q", v)
+			}
+			return checkRange(field, big.NewFloat(float64(d)))
+		case json.Number:
+			return checkValue(Field{Type: NanosecondsType, Min: field.Min, Max: field.Max}, v)
+		default:
+			return fmt.Errorf("expected a duration, got %v", value)
+		}
+	case StringListType, AddressListType, HashListType, NodeIDListType:
+		list, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected a list, got %v", value)
+		}
+		for _, element := range list {
+			if err := checkElement(field.Type, element); err != nil {
+				return err
+			}
+			if str, _ := element.(string); len(field.Values) > 0 && !slices.Contains(field.Values, str) {
+				return fmt.Errorf("expected elements among %s, got %q", strings.Join(field.Values, ", "), str)
+			}
+		}
+	case AnyType:
+		return nil
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type)
+	}
+	return nil
+}
+
+func checkElement(valueType ValueType, element interface{}) error {
+	str, ok := element.(string)
+	if !ok {
+		return fmt.Errorf("expected a string, got %v", element)
+	}
+	switch valueType {
+	case AddressType, AddressListType:
+		if !common.IsHexAddress(str) {
+			return fmt.Errorf("invalid address %q", str)
+		}
+	case HashListType:
+		hashBytes, err := hexutil.Decode(str)
+		if err != nil || len(hashBytes) != common.HashLength {
+			return fmt.Errorf("invalid hash %q", str)
+		}
+	case NodeIDListType:
+		if _, err := ids.NodeIDFromString(str); err != nil {
+			return fmt.Errorf("invalid node ID %q", str)
+		}
+	}
+	return nil
+}
+
+func checkRange(field Field, value *big.Float) error {
+	if field.Min != nil && value.Cmp(big.NewFloat(*field.Min)) < 0 {
+		return fmt.Errorf("%s is below the minimum of %s", value.Text('f', -1), big.NewFloat(*field.Min).Text('f', -1))
+	}
+	if field.Max != nil && value.Cmp(big.NewFloat(*field.Max)) > 0 {
+		return fmt.Errorf("%s is above the maximum of %s", value.Text('f', -1), big.NewFloat(*field.Max).Text('f', -1))
+	}
+	return nil
+}
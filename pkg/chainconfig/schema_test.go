@@ -0,0 +1,120 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package chainconfig
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValue(t *testing.T) {
+	require := require.New(t)
+
+	value, err := EvmSchema.ParseValue("pruning-enabled", "false")
+	require.NoError(err)
+	require.Equal(false, value)
+
+	value, err = EvmSchema.ParseValue("eth-apis", "eth, eth-filter,net")
+	require.NoError(err)
+	require.Equal([]interface{}{"eth", "eth-filter", "net"}, value)
+
+	value, err = SubnetSchema.ParseValue("proposerMinBlockDelay", "1s")
+	require.NoError(err)
+	require.Equal(json.Number("1000000000"), value)
+
+	_, err = EvmSchema.ParseValue("pruning-enabled", "maybe")
+	require.ErrorContains(err, "expected true or false")
+	_, err = EvmSchema.ParseValue("state-sync-request-size", "70000")
+	require.ErrorContains(err, "above the maximum")
+	_, err = EvmSchema.ParseValue("log-level", "verbose")
+	require.ErrorContains(err, "expected one of")
+	_, err = EvmSchema.ParseValue("feeRecipient", "0x1234")
+	require.ErrorContains(err, "invalid address")
+	_, err = EvmSchema.ParseValue("prunning-enabled", "true")
+	require.ErrorIs(err, ErrUnknownKey)
+
+	require.Equal(json.Number("12"), ParseRawValue("12"))
+	require.Equal("not json", ParseRawValue("not json"))
+}
+
+func TestValidate(t *testing.T) {
+	require := require.New(t)
+
+	config, err := Decode([]byte(`{
+		"pruning-enabled": true,
+		"commit-interval": 4096,
+		"api-max-duration": "30s",
+		"log_level": "info",
+		"consensusParameters": {"k": 20}
+	}`))
+	require.NoError(err)
+	warnings, err := EvmSchema.Validate(config)
+	require.NoError(err)
+	require.Len(warnings, 2)
+	require.Contains(warnings[0], `"consensusParameters"`)
+	require.Contains(warnings[1], `did you mean "log-level"?`)
+
+	config, err = Decode([]byte(`{"pruning-enabled": "yes", "tx-pool-price-limit": -1}`))
+	require.NoError(err)
+	_, err = EvmSchema.Validate(config)
+	require.ErrorContains(err, "pruning-enabled: expected a bool")
+	require.ErrorContains(err, "tx-pool-price-limit: -1 is below the minimum of 0")
+
+	// settings valid one by one, but not together
+	config, err = Decode([]byte(`{"pruning-enabled": true, "commit-interval": 0}`))
+	require.NoError(err)
+	_, err = EvmSchema.Validate(config)
+	require.ErrorContains(err, "commit interval of 0")
+
+	config, err = Decode([]byte(`{"consensusParameters": {"k": 20, "alpha": 25}, "consensusParamters": {}}`))
+	require.NoError(err)
+	warnings, err = SubnetSchema.Validate(config)
+	require.ErrorContains(err, "consensus")
+	require.Len(warnings, 1)
+}
+
+func TestSetGetValue(t *testing.T) {
+	require := require.New(t)
+
+	config := map[string]interface{}{}
+	require.NoError(SetValue(config, "consensusParameters.k", json.Number("20")))
+	require.NoError(SetValue(config, "validatorOnly", true))
+	value, ok := GetValue(config, "consensusParameters.k")
+	require.True(ok)
+	require.Equal(json.Number("20"), value)
+	_, ok = GetValue(config, "consensusParameters.alpha")
+	require.False(ok)
+
+	require.ErrorContains(SetValue(config, "validatorOnly.value", true), "validatorOnly is not an object")
+
+	configBytes, err := Encode(config)
+	require.NoError(err)
+	decoded, err := Decode(configBytes)
+	require.NoError(err)
+	require.Equal(config, decoded)
+}
+
+func TestEvmFields(t *testing.T) {
+	require := require.New(t)
+
+	// the overrides must follow the keys of subnet-evm
+	for key, field := range evmFieldOverrides {
+		require.Contains(evmFields, key)
+		require.Equal(field, evmFields[key])
+	}
+	require.Equal(duration, evmFields["tx-pool-rejournal"])
+	require.Equal(Field{Type: HashListType}, evmFields["allow-unprotected-tx-hashes"])
+	require.Equal(Field{Type: AddressListType}, evmFields["priority-regossip-addresses"])
+	require.Equal(nonNegative, evmFields["populate-missing-tries"])
+	require.Equal(Field{Type: IntType, Min: bound(0), Max: bound(65535)}, evmFields["state-sync-request-size"])
+
+	// types added by a later subnet-evm accept any value
+	require.Equal(Field{Type: AnyType}, getEvmField(reflect.TypeOf(map[string]int{})))
+	schema := Schema{Name: "test", Fields: map[string]Field{"new-key": {Type: AnyType}}}
+	value, err := schema.ParseValue("new-key", `{"a":1}`)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"a": json.Number("1")}, value)
+}
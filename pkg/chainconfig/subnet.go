@@ -0,0 +1,58 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package chainconfig
+
+import (
+	"encoding/json"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/subnets"
+)
+
+// SubnetSchema describes the subnet config of avalanchego
+var SubnetSchema = Schema{
+	Name:   "avalanchego subnet config",
+	Fields: subnetFields,
+	Verify: verifySubnetConfig,
+}
+
+var subnetFields = map[string]Field{
+	// gossip
+	"gossipAcceptedFrontierValidatorSize":    nonNegative,
+	"gossipAcceptedFrontierNonValidatorSize": nonNegative,
+	"gossipAcceptedFrontierPeerSize":         nonNegative,
+	"gossipOnAcceptValidatorSize":            nonNegative,
+	"gossipOnAcceptNonValidatorSize":         nonNegative,
+	"gossipOnAcceptPeerSize":                 nonNegative,
+	"appGossipValidatorSize":                 nonNegative,
+	"appGossipNonValidatorSize":              nonNegative,
+	"appGossipPeerSize":                      nonNegative,
+
+	// access
+	"validatorOnly": {Type: BoolType},
+	"allowedNodes":  {Type: NodeIDListType},
+
+	// consensus
+	"consensusParameters.k":                     positive,
+	"consensusParameters.alpha":                 positive,
+	"consensusParameters.betaVirtuous":          positive,
+	"consensusParameters.betaRogue":             positive,
+	"consensusParameters.concurrentRepolls":     positive,
+	"consensusParameters.optimalProcessing":     positive,
+	"consensusParameters.maxOutstandingItems":   positive,
+	"consensusParameters.maxItemProcessingTime": {Type: NanosecondsType, Min: bound(1)},
+
+	"proposerMinBlockDelay": {Type: NanosecondsType, Min: bound(0)},
+}
+
+// verifySubnetConfig checks the consensus parameters and the access settings, the
+// way avalanchego does on startup
+func verifySubnetConfig(configBytes []byte) error {
+	config := subnets.Config{
+		ConsensusParameters: snowball.DefaultParameters,
+	}
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return err
+	}
+	return config.Valid()
+}
@@ -21,6 +21,9 @@ const (
 	GenesisFileName             = "genesis.json"
 	GenesisMainnetFileName      = "genesis_mainnet.json"
 	ElasticSubnetConfigFileName = "elastic_subnet_config.json"
+	GenesisSchemaFileName       = "genesis_schema.json"
+	DescribeHookFileName        = "describe_hook"
+	PendingRenewalsFileName     = "pending_renewals.json"
 	SidecarSuffix               = SuffixSeparator + SidecarFileName
 	GenesisSuffix               = SuffixSeparator + GenesisFileName
 	NodeFileName                = "node.json"
@@ -103,6 +106,8 @@ const (
 	IsSubnetSyncedPlaybook                = "playbook/isSubnetSynced.yml"
 	TrackSubnetPlaybook                   = "playbook/trackSubnet.yml"
 	AvalancheGoVersionPlaybook            = "playbook/avalancheGoVersion.yml"
+	UpgradeSubnetPlaybook                 = "playbook/upgradeSubnet.yml"
+	GetChainConfigPlaybook                = "playbook/getChainConfig.yml"
 	IsBootstrappedJSONFile                = "isBootstrapped.json"
 	AvalancheGoVersionJSONFile            = "avalancheGoVersion.json"
 	NodeIDJSONFile                        = "nodeID.json"
@@ -113,6 +118,7 @@ const (
 	AnsibleInventoryFlag                  = "-i"
 	AnsibleExtraArgsIdentitiesOnlyFlag    = "--ssh-extra-args='-o IdentitiesOnly=yes'"
 	AnsibleExtraVarsFlag                  = "--extra-vars"
+	CloudNodeChainConfigDir               = "/home/ubuntu/.avalanchego/configs/chains"
 	DefaultConfigFileName                 = ".avalanche-cli"
 	DefaultConfigFileType                 = "json"
 	WriteReadReadPerms                    = 0o644
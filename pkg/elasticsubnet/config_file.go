@@ -0,0 +1,81 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package elasticsubnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+)
+
+// maximum stake duration accepted by the prompts
+const maxStakeDurationLimit = 365 * 24 * time.Hour
+
+// LoadElasticSubnetConfigFile reads an elastic subnet config from the JSON file at [path],
+// in the same format written by CreateElasticSubnetConfig, and validates it
+func LoadElasticSubnetConfigFile(path string) (models.ElasticSubnetConfig, error) {
+	configBytes, err := os.ReadFile(path)
+	if err != nil {
+		return models.ElasticSubnetConfig{}, err
+	}
+	var config models.ElasticSubnetConfig
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return models.ElasticSubnetConfig{}, fmt.Errorf("invalid elastic subnet config file %s: %w", path, err)
+	}
+	if err := ValidateElasticSubnetConfig(config); err != nil {
+		return models.ElasticSubnetConfig{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// ValidateElasticSubnetConfig applies to [config] the same range checks done by
+// the elastic subnet config prompts. All the invalid parameters are reported
+func ValidateElasticSubnetConfig(config models.ElasticSubnetConfig) error {
+	problems := []string{}
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+	check(config.MaxSupply >= config.InitialSupply,
+		"MaxSupply (%d) must be greater than or equal to InitialSupply (%d)", config.MaxSupply, config.InitialSupply)
+	check(config.MinConsumptionRate <= reward.PercentDenominator,
+		"MinConsumptionRate (%d) must be less than or equal to %d", config.MinConsumptionRate, reward.PercentDenominator)
+	check(config.MaxConsumptionRate <= reward.PercentDenominator,
+		"MaxConsumptionRate (%d) must be less than or equal to %d", config.MaxConsumptionRate, reward.PercentDenominator)
+	check(config.MaxConsumptionRate >= config.MinConsumptionRate,
+		"MaxConsumptionRate (%d) must be greater than or equal to MinConsumptionRate (%d)", config.MaxConsumptionRate, config.MinConsumptionRate)
+	check(config.MinValidatorStake > 0, "MinValidatorStake must be greater than 0")
+	check(config.MinValidatorStake <= config.InitialSupply,
+		"MinValidatorStake (%d) must be less than or equal to InitialSupply (%d)", config.MinValidatorStake, config.InitialSupply)
+	check(config.MaxValidatorStake > config.MinValidatorStake,
+		"MaxValidatorStake (%d) must be greater than MinValidatorStake (%d)", config.MaxValidatorStake, config.MinValidatorStake)
+	check(config.MaxValidatorStake <= config.MaxSupply,
+		"MaxValidatorStake (%d) must be less than or equal to MaxSupply (%d)", config.MaxValidatorStake, config.MaxSupply)
+	check(config.MinStakeDuration > 0, "MinStakeDuration must be greater than 0")
+	check(config.MinStakeDuration <= maxStakeDurationLimit,
+		"MinStakeDuration (%s) must be less than or equal to %s", config.MinStakeDuration, maxStakeDurationLimit)
+	check(config.MaxStakeDuration >= config.MinStakeDuration,
+		"MaxStakeDuration (%s) must be greater than or equal to MinStakeDuration (%s)", config.MaxStakeDuration, config.MinStakeDuration)
+	check(config.MaxStakeDuration <= maxStakeDurationLimit,
+		"MaxStakeDuration (%s) must be less than or equal to %s", config.MaxStakeDuration, maxStakeDurationLimit)
+	check(config.MinDelegationFee <= reward.PercentDenominator,
+		"MinDelegationFee (%d) must be less than or equal to %d", config.MinDelegationFee, reward.PercentDenominator)
+	check(config.MinDelegatorStake > 0, "MinDelegatorStake must be greater than 0")
+	check(config.MaxValidatorWeightFactor > 0, "MaxValidatorWeightFactor must be greater than 0")
+	check(config.MaxValidatorWeightFactor <= math.MaxInt8,
+		"MaxValidatorWeightFactor (%d) must be less than or equal to %d", config.MaxValidatorWeightFactor, math.MaxInt8)
+	check(config.UptimeRequirement <= reward.PercentDenominator,
+		"UptimeRequirement (%d) must be less than or equal to %d", config.UptimeRequirement, reward.PercentDenominator)
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid elastic subnet config:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
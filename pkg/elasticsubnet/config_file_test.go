@@ -0,0 +1,69 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package elasticsubnet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/stretchr/testify/require"
+)
+
+func defaultElasticSubnetConfig() models.ElasticSubnetConfig {
+	return models.ElasticSubnetConfig{
+		InitialSupply:            defaultInitialSupply,
+		MaxSupply:                defaultMaximumSupply,
+		MinConsumptionRate:       defaultMinConsumptionRate * reward.PercentDenominator,
+		MaxConsumptionRate:       defaultMaxConsumptionRate * reward.PercentDenominator,
+		MinValidatorStake:        defaultMinValidatorStake,
+		MaxValidatorStake:        defaultMaxValidatorStake,
+		MinStakeDuration:         defaultMinStakeDuration,
+		MaxStakeDuration:         defaultMaxStakeDuration,
+		MinDelegationFee:         defaultMinDelegationFee,
+		MinDelegatorStake:        defaultMinDelegatorStake,
+		MaxValidatorWeightFactor: defaultMaxValidatorWeightFactor,
+		UptimeRequirement:        defaultUptimeRequirement * reward.PercentDenominator,
+	}
+}
+
+func TestValidateElasticSubnetConfig(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(ValidateElasticSubnetConfig(defaultElasticSubnetConfig()))
+
+	config := defaultElasticSubnetConfig()
+	config.MaxSupply = config.InitialSupply - 1
+	config.MinConsumptionRate = config.MaxConsumptionRate + 1
+	config.MaxValidatorWeightFactor = 0
+	err := ValidateElasticSubnetConfig(config)
+	require.ErrorContains(err, "MaxSupply")
+	require.ErrorContains(err, "MaxConsumptionRate")
+	require.ErrorContains(err, "MaxValidatorWeightFactor")
+	require.NotContains(err.Error(), "UptimeRequirement")
+
+	config = defaultElasticSubnetConfig()
+	config.MaxStakeDuration = 2 * maxStakeDurationLimit
+	require.ErrorContains(ValidateElasticSubnetConfig(config), "MaxStakeDuration")
+}
+
+func TestLoadElasticSubnetConfigFile(t *testing.T) {
+	require := require.New(t)
+
+	config := defaultElasticSubnetConfig()
+	configBytes, err := json.Marshal(config)
+	require.NoError(err)
+	path := filepath.Join(t.TempDir(), "elastic.json")
+	require.NoError(os.WriteFile(path, configBytes, 0o600))
+	loaded, err := LoadElasticSubnetConfigFile(path)
+	require.NoError(err)
+	require.Equal(config, loaded)
+
+	require.NoError(os.WriteFile(path, []byte(`{"InitialSupply": 10, "MaxSupply": 5}`), 0o600))
+	_, err = LoadElasticSubnetConfigFile(path)
+	require.ErrorContains(err, "MaxSupply (5) must be greater than or equal to InitialSupply (10)")
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package elasticsubnet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+)
+
+// SupplyProjection is the projected supply of an elastic subnet token at the end of a staking period
+type SupplyProjection struct {
+	Period  int
+	Elapsed time.Duration
+	Minted  uint64
+	Supply  uint64
+}
+
+// NewRewardCalculator returns the calculator the P-Chain uses for the rewards of [config].
+// Elastic subnets share the minting period of the primary network
+func NewRewardCalculator(config models.ElasticSubnetConfig) reward.Calculator {
+	return reward.NewCalculator(reward.Config{
+		MaxConsumptionRate: config.MaxConsumptionRate,
+		MinConsumptionRate: config.MinConsumptionRate,
+		MintingPeriod:      genesis.MainnetParams.RewardConfig.MintingPeriod,
+		SupplyCap:          config.MaxSupply,
+	})
+}
+
+// SimulateSupply projects the supply growth of [config], starting at [supply], when
+// [staked] tokens are staked for [periods] consecutive staking periods of [stakingPeriod].
+// Rewards are not restaked, so the staked amount stays the same on every period
+func SimulateSupply(
+	config models.ElasticSubnetConfig,
+	supply uint64,
+	staked uint64,
+	stakingPeriod time.Duration,
+	periods int,
+) ([]SupplyProjection, error) {
+	switch {
+	case stakingPeriod < config.MinStakeDuration || stakingPeriod > config.MaxStakeDuration:
+		return nil, fmt.Errorf("staking period %s must be between MinStakeDuration (%s) and MaxStakeDuration (%s)",
+			stakingPeriod, config.MinStakeDuration, config.MaxStakeDuration)
+	case staked > supply:
+		return nil, fmt.Errorf("staked amount %d can't be greater than the supply %d", staked, supply)
+	case supply > config.MaxSupply:
+		return nil, fmt.Errorf("supply %d can't be greater than MaxSupply (%d)", supply, config.MaxSupply)
+	case periods <= 0:
+		return nil, fmt.Errorf("number of periods must be positive")
+	}
+	calculator := NewRewardCalculator(config)
+	projections := []SupplyProjection{}
+	for period := 1; period <= periods; period++ {
+		minted := calculator.Calculate(stakingPeriod, staked, supply)
+		supply += minted
+		projections = append(projections, SupplyProjection{
+			Period:  period,
+			Elapsed: time.Duration(period) * stakingPeriod,
+			Minted:  minted,
+			Supply:  supply,
+		})
+	}
+	return projections, nil
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package elasticsubnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateSupply(t *testing.T) {
+	require := require.New(t)
+	config := defaultElasticSubnetConfig()
+
+	projections, err := SimulateSupply(config, config.InitialSupply, config.InitialSupply/2, config.MaxStakeDuration, 20)
+	require.NoError(err)
+	require.Len(projections, 20)
+	previous := config.InitialSupply
+	for _, projection := range projections {
+		require.Positive(projection.Minted)
+		require.Equal(previous+projection.Minted, projection.Supply)
+		require.LessOrEqual(projection.Supply, config.MaxSupply)
+		previous = projection.Supply
+	}
+	// minting slows down as the supply gets closer to the cap
+	require.Less(projections[19].Minted, projections[0].Minted)
+	require.Equal(20*config.MaxStakeDuration, projections[19].Elapsed)
+
+	// longer staking periods mint more per token and time
+	short, err := SimulateSupply(config, config.InitialSupply, config.InitialSupply/2, config.MinStakeDuration, 1)
+	require.NoError(err)
+	require.Less(
+		float64(short[0].Minted)/float64(config.MinStakeDuration),
+		float64(projections[0].Minted)/float64(config.MaxStakeDuration),
+	)
+
+	_, err = SimulateSupply(config, config.InitialSupply, config.InitialSupply/2, config.MinStakeDuration/2, 1)
+	require.ErrorContains(err, "MinStakeDuration")
+	_, err = SimulateSupply(config, config.InitialSupply, config.InitialSupply+1, config.MinStakeDuration, 1)
+	require.ErrorContains(err, "staked amount")
+}
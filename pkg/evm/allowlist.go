@@ -0,0 +1,108 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/deployerallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// allow list roles
+const (
+	NoRole      = "none"
+	EnabledRole = "enabled"
+	AdminRole   = "admin"
+	// the manager role is only known by the Subnet-EVM versions that introduced it
+	ManagerRole = "manager"
+)
+
+// AllowListPrecompiles are the addresses of the precompiles that have an allow list, by short name
+var AllowListPrecompiles = map[string]common.Address{
+	"deployer":      deployerallowlist.ContractAddress,
+	"tx":            txallowlist.ContractAddress,
+	"minter":        nativeminter.ContractAddress,
+	"feemanager":    feemanager.ContractAddress,
+	"rewardmanager": rewardmanager.ContractAddress,
+}
+
+// ErrPrecompileNotActivated is returned when calling a precompile that is not activated on the chain
+var ErrPrecompileNotActivated = errors.New("precompile is not activated")
+
+// values returned by readAllowList for each role
+var allowListRoles = []string{NoRole, EnabledRole, AdminRole, ManagerRole}
+
+var (
+	setRoleSignatures = map[string]string{
+		NoRole:      "setNone(address)",
+		EnabledRole: "setEnabled(address)",
+		AdminRole:   "setAdmin(address)",
+		ManagerRole: "setManager(address)",
+	}
+	readAllowListSignature = "readAllowList(address)"
+)
+
+// GetAllowListPrecompileNames returns the short names of the allow list precompiles, sorted
+func GetAllowListPrecompileNames() []string {
+	names := []string{}
+	for name := range AllowListPrecompiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PackSetAllowListRole packs the call that gives [role] to [address]
+func PackSetAllowListRole(address common.Address, role string) ([]byte, error) {
+	signature, ok := setRoleSignatures[role]
+	if !ok {
+		return nil, fmt.Errorf("invalid allow list role %q", role)
+	}
+	return packAddressCall(signature, address), nil
+}
+
+// PackReadAllowList packs the call that reads the role of [address]
+func PackReadAllowList(address common.Address) []byte {
+	return packAddressCall(readAllowListSignature, address)
+}
+
+// UnpackAllowListRole returns the role name of a readAllowList result
+func UnpackAllowListRole(result []byte) (string, error) {
+	if len(result) != common.HashLength {
+		return "", fmt.Errorf("invalid readAllowList result length %d", len(result))
+	}
+	value := new(big.Int).SetBytes(result)
+	if !value.IsUint64() || value.Uint64() >= uint64(len(allowListRoles)) {
+		return "", fmt.Errorf("unknown allow list role %s", value)
+	}
+	return allowListRoles[value.Uint64()], nil
+}
+
+// GetAllowListRole returns the role of [address] on the allow list of [precompileAddress]
+func GetAllowListRole(rpcURL string, precompileAddress common.Address, address common.Address) (string, error) {
+	result, err := Call(rpcURL, precompileAddress, PackReadAllowList(address))
+	if err != nil {
+		return "", err
+	}
+	// calls to addresses without code return nothing
+	if len(result) == 0 {
+		return "", ErrPrecompileNotActivated
+	}
+	return UnpackAllowListRole(result)
+}
+
+func packAddressCall(signature string, address common.Address) []byte {
+	input := make([]byte, 0, contract.SelectorLen+common.HashLength)
+	input = append(input, contract.CalculateFunctionSelector(signature)...)
+	return append(input, common.LeftPadBytes(address.Bytes(), common.HashLength)...)
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowListPacking(t *testing.T) {
+	require := require.New(t)
+	address := common.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC")
+
+	// matches the encoding of the precompile itself
+	for role, expectedRole := range map[string]allowlist.Role{
+		NoRole:      allowlist.NoRole,
+		EnabledRole: allowlist.EnabledRole,
+		AdminRole:   allowlist.AdminRole,
+	} {
+		input, err := PackSetAllowListRole(address, role)
+		require.NoError(err)
+		expected, err := allowlist.PackModifyAllowList(address, expectedRole)
+		require.NoError(err)
+		require.Equal(expected, input)
+	}
+	input, err := PackSetAllowListRole(address, ManagerRole)
+	require.NoError(err)
+	require.Len(input, 36)
+	_, err = PackSetAllowListRole(address, "owner")
+	require.ErrorContains(err, "invalid allow list role")
+	require.Equal(allowlist.PackReadAllowList(address), PackReadAllowList(address))
+
+	role, err := UnpackAllowListRole(common.Hash(allowlist.AdminRole).Bytes())
+	require.NoError(err)
+	require.Equal(AdminRole, role)
+	role, err = UnpackAllowListRole(common.Hash(allowlist.NoRole).Bytes())
+	require.NoError(err)
+	require.Equal(NoRole, role)
+	_, err = UnpackAllowListRole(common.BigToHash(common.Big32).Bytes())
+	require.ErrorContains(err, "unknown allow list role")
+}
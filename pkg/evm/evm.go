@@ -0,0 +1,152 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// how long to wait for a sent tx to be accepted
+const receiptTimeout = time.Minute
+
+// GetRPCURL returns the RPC URL of [blockchainID] on the node API [endpoint]
+func GetRPCURL(endpoint string, blockchainID ids.ID) string {
+	return fmt.Sprintf("%s/ext/bc/%s/rpc", endpoint, blockchainID)
+}
+
+// Call executes a read only call of [data] against the contract at [to]
+func Call(rpcURL string, to common.Address, data []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.RequestTimeout)
+	defer cancel()
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return client.CallContract(ctx, interfaces.CallMsg{To: &to, Data: data}, nil)
+}
+
+// SendTx signs with [privateKey] a dynamic fee tx that sends [value] and [data]
+// to [to], issues it, and waits for its receipt. Reverted txs are returned as errors
+func SendTx(
+	rpcURL string,
+	privateKey *ecdsa.PrivateKey,
+	to common.Address,
+	value *big.Int,
+	data []byte,
+) (*types.Receipt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), receiptTimeout)
+	defer cancel()
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	nonce, err := client.NonceAt(ctx, from, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce of %s: %w", from, err)
+	}
+	gasTipCap, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	baseFee, err := client.EstimateBaseFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// leave room for the base fee to double before the tx is accepted
+	gasFeeCap := new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), gasTipCap)
+	gas, err := client.EstimateGas(ctx, interfaces.CallMsg{
+		From:  from,
+		To:    &to,
+		Value: value,
+		Data:  data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	tx, err := types.SignNewTx(privateKey, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       gas,
+		To:        &to,
+		Value:     value,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to send tx: %w", err)
+	}
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt of tx %s: %w", tx.Hash(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("tx %s reverted", tx.Hash())
+	}
+	return receipt, nil
+}
+
+// GetCodeHash returns the hash of the code deployed at [address], which is the
+// empty code hash for accounts without code
+func GetCodeHash(rpcURL string, address common.Address) (common.Hash, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.RequestTimeout)
+	defer cancel()
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer client.Close()
+	code, err := client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get code of %s: %w", address, err)
+	}
+	return crypto.Keccak256Hash(code), nil
+}
+
+// IsChecksummedAddress tells if [addressStr] is a hex address with a valid EIP-55 checksum
+func IsChecksummedAddress(addressStr string) bool {
+	return common.IsHexAddress(addressStr) && common.HexToAddress(addressStr).Hex() == addressStr
+}
+
+// GetChainConfig returns the chain config, with its upgrades, of the chain at [rpcURL]
+func GetChainConfig(rpcURL string) (params.ChainConfigWithUpgradesJSON, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.RequestTimeout)
+	defer cancel()
+	var chainConfig params.ChainConfigWithUpgradesJSON
+	client, err := rpc.DialContext(ctx, rpcURL)
+	if err != nil {
+		return chainConfig, err
+	}
+	defer client.Close()
+	if err := client.CallContext(ctx, &chainConfig, "eth_getChainConfig"); err != nil {
+		return chainConfig, fmt.Errorf("failed to get chain config: %w", err)
+	}
+	return chainConfig, nil
+}
@@ -0,0 +1,18 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+)
+
+// GetFeeConfig returns the fee config currently stored in the fee manager precompile
+func GetFeeConfig(rpcURL string) (commontype.FeeConfig, error) {
+	result, err := Call(rpcURL, feemanager.ContractAddress, feemanager.PackGetFeeConfigInput())
+	if err != nil {
+		return commontype.FeeConfig{}, err
+	}
+	return feemanager.UnpackFeeConfigInput(result)
+}
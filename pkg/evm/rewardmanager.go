@@ -0,0 +1,98 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/constants"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// reward modes of the reward manager precompile
+const (
+	FeeRecipientsRewardMode = "fee recipients"
+	RewardAddressRewardMode = "reward address"
+	DisabledRewardMode      = "disabled"
+)
+
+// RewardConfig is the reward behavior set in the reward manager precompile
+type RewardConfig struct {
+	FeeRecipientsAllowed bool
+	RewardAddress        common.Address
+}
+
+// Mode returns how the block fees are rewarded under [c]
+func (c RewardConfig) Mode() string {
+	switch {
+	case c.FeeRecipientsAllowed:
+		return FeeRecipientsRewardMode
+	case c.RewardAddress == constants.BlackholeAddr:
+		return DisabledRewardMode
+	default:
+		return RewardAddressRewardMode
+	}
+}
+
+// GetRewardConfig returns the reward config stored in the reward manager precompile
+func GetRewardConfig(rpcURL string) (RewardConfig, error) {
+	allowed, err := callRewardManager(rpcURL, "areFeeRecipientsAllowed")
+	if err != nil {
+		return RewardConfig{}, err
+	}
+	address, err := callRewardManager(rpcURL, "currentRewardAddress")
+	if err != nil {
+		return RewardConfig{}, err
+	}
+	config := RewardConfig{}
+	var ok bool
+	if config.FeeRecipientsAllowed, ok = allowed.(bool); !ok {
+		return RewardConfig{}, fmt.Errorf("unexpected areFeeRecipientsAllowed output %v", allowed)
+	}
+	if config.RewardAddress, ok = address.(common.Address); !ok {
+		return RewardConfig{}, fmt.Errorf("unexpected currentRewardAddress output %v", address)
+	}
+	return config, nil
+}
+
+// PackSetRewardConfig packs the call that sets the reward [mode]. [rewardAddress]
+// is only used by the reward address mode
+func PackSetRewardConfig(mode string, rewardAddress common.Address) ([]byte, error) {
+	switch mode {
+	case FeeRecipientsRewardMode:
+		return rewardmanager.PackAllowFeeRecipients()
+	case RewardAddressRewardMode:
+		if rewardAddress == (common.Address{}) {
+			return nil, rewardmanager.ErrEmptyRewardAddress
+		}
+		return rewardmanager.PackSetRewardAddress(rewardAddress)
+	case DisabledRewardMode:
+		return rewardmanager.PackDisableRewards()
+	}
+	return nil, fmt.Errorf("invalid reward mode %q", mode)
+}
+
+// callRewardManager calls the reward manager view [method], which has a single output
+func callRewardManager(rpcURL string, method string) (interface{}, error) {
+	input, err := rewardmanager.RewardManagerABI.Pack(method)
+	if err != nil {
+		return nil, err
+	}
+	result, err := Call(rpcURL, rewardmanager.ContractAddress, input)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, ErrPrecompileNotActivated
+	}
+	outputs, err := rewardmanager.RewardManagerABI.Unpack(method, result)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputs) != 1 {
+		return nil, fmt.Errorf("unexpected %s output %v", method, outputs)
+	}
+	return outputs[0], nil
+}
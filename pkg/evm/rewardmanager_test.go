@@ -0,0 +1,37 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/constants"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewardConfig(t *testing.T) {
+	require := require.New(t)
+	address := common.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC")
+
+	require.Equal(FeeRecipientsRewardMode, RewardConfig{FeeRecipientsAllowed: true}.Mode())
+	require.Equal(DisabledRewardMode, RewardConfig{RewardAddress: constants.BlackholeAddr}.Mode())
+	require.Equal(RewardAddressRewardMode, RewardConfig{RewardAddress: address}.Mode())
+
+	input, err := PackSetRewardConfig(RewardAddressRewardMode, address)
+	require.NoError(err)
+	expected, err := rewardmanager.PackSetRewardAddress(address)
+	require.NoError(err)
+	require.Equal(expected, input)
+	_, err = PackSetRewardConfig(RewardAddressRewardMode, common.Address{})
+	require.ErrorIs(err, rewardmanager.ErrEmptyRewardAddress)
+	input, err = PackSetRewardConfig(DisabledRewardMode, common.Address{})
+	require.NoError(err)
+	expected, err = rewardmanager.PackDisableRewards()
+	require.NoError(err)
+	require.Equal(expected, input)
+	_, err = PackSetRewardConfig("burn", common.Address{})
+	require.ErrorContains(err, "invalid reward mode")
+}
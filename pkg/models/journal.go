@@ -0,0 +1,54 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package models
+
+import "github.com/ava-labs/avalanchego/ids"
+
+// operations that issue several txs, and keep a journal of the confirmed ones
+const (
+	DeployJournal           = "Deploy"
+	ElasticTransformJournal = "ElasticTransform"
+	AddValidatorsJournal    = "AddValidators"
+	SetWeightJournal        = "SetWeight"
+)
+
+// Journal keeps, by step name, the IDs of the txs already confirmed by a
+// multi-tx operation, so that rerunning the operation resumes from the last
+// confirmed tx instead of issuing everything again
+type Journal map[string]ids.ID
+
+func journalKey(network Network, operation string) string {
+	return network.String() + "/" + operation
+}
+
+// GetJournalStep returns the tx ID recorded for [step] of [operation] on [network]
+func (sc Sidecar) GetJournalStep(network Network, operation string, step string) (ids.ID, bool) {
+	txID, ok := sc.Journals[journalKey(network, operation)][step]
+	return txID, ok
+}
+
+// SetJournalStep records [txID] as the confirmed tx of [step] of [operation] on [network]
+func (sc *Sidecar) SetJournalStep(network Network, operation string, step string, txID ids.ID) {
+	if sc.Journals == nil {
+		sc.Journals = map[string]Journal{}
+	}
+	key := journalKey(network, operation)
+	if sc.Journals[key] == nil {
+		sc.Journals[key] = Journal{}
+	}
+	sc.Journals[key][step] = txID
+}
+
+// ClearJournal removes the journal of [operation] on [network], once it is completed
+func (sc *Sidecar) ClearJournal(network Network, operation string) {
+	delete(sc.Journals, journalKey(network, operation))
+}
+
+// ClearJournalStep removes [step] from the journal of [operation] on [network]
+func (sc *Sidecar) ClearJournalStep(network Network, operation string, step string) {
+	key := journalKey(network, operation)
+	delete(sc.Journals[key], step)
+	if len(sc.Journals[key]) == 0 {
+		delete(sc.Journals, key)
+	}
+}
@@ -42,6 +42,19 @@ func (s Network) NetworkID() (uint32, error) {
 	return 0, fmt.Errorf("unsupported network")
 }
 
+// Endpoint returns the node API endpoint used by the CLI for [s]
+func (s Network) Endpoint() (string, error) {
+	switch s {
+	case Mainnet:
+		return constants.MainnetAPIEndpoint, nil
+	case Fuji:
+		return constants.FujiAPIEndpoint, nil
+	case Local:
+		return constants.LocalAPIEndpoint, nil
+	}
+	return "", fmt.Errorf("network %s not supported", s)
+}
+
 func NetworkFromString(s string) Network {
 	switch s {
 	case Mainnet.String():
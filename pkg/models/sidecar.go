@@ -39,6 +39,19 @@ type Sidecar struct {
 	ElasticSubnet   map[string]ElasticSubnet
 	ImportedFromAPM bool
 	ImportedVMID    string
+	// names of the additional blockchains deployed into this subnet.
+	// each one has its own configuration (sidecar, genesis, chain configs),
+	// with its Subnet field pointing back to this subnet
+	Chains []string
+	// confirmed txs of multi-tx operations that are not completed yet,
+	// by network and operation
+	Journals map[string]Journal
+}
+
+// IsSubnetChain returns true if the sidecar describes an additional blockchain
+// of another subnet, instead of the subnet's own (first) blockchain
+func (sc Sidecar) IsSubnetChain() bool {
+	return sc.Subnet != "" && sc.Subnet != sc.Name
 }
 
 func (sc Sidecar) GetVMID() (string, error) {
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanche-network-runner/utils"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/stretchr/testify/require"
 )
 
@@ -38,3 +39,45 @@ func TestGetVMID_derived(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(expectedVMID.String(), vmid)
 }
+
+func TestIsSubnetChain(t *testing.T) {
+	assert := require.New(t)
+	assert.False(Sidecar{Name: "subnet", Subnet: "subnet"}.IsSubnetChain())
+	assert.False(Sidecar{Name: "subnet"}.IsSubnetChain())
+	assert.True(Sidecar{Name: "chain", Subnet: "subnet"}.IsSubnetChain())
+}
+
+func TestJournal(t *testing.T) {
+	require := require.New(t)
+	sc := Sidecar{Name: "subnet"}
+	txID := ids.GenerateTestID()
+
+	_, ok := sc.GetJournalStep(Fuji, DeployJournal, "CreateSubnetTx")
+	require.False(ok)
+
+	sc.SetJournalStep(Fuji, DeployJournal, "CreateSubnetTx", txID)
+	got, ok := sc.GetJournalStep(Fuji, DeployJournal, "CreateSubnetTx")
+	require.True(ok)
+	require.Equal(txID, got)
+
+	// journals are kept per network and operation
+	_, ok = sc.GetJournalStep(Mainnet, DeployJournal, "CreateSubnetTx")
+	require.False(ok)
+	_, ok = sc.GetJournalStep(Fuji, ElasticTransformJournal, "CreateSubnetTx")
+	require.False(ok)
+
+	sc.ClearJournal(Fuji, DeployJournal)
+	_, ok = sc.GetJournalStep(Fuji, DeployJournal, "CreateSubnetTx")
+	require.False(ok)
+
+	// single steps can be cleared too
+	sc.SetJournalStep(Fuji, SetWeightJournal, "node1", txID)
+	sc.SetJournalStep(Fuji, SetWeightJournal, "node2", txID)
+	sc.ClearJournalStep(Fuji, SetWeightJournal, "node1")
+	_, ok = sc.GetJournalStep(Fuji, SetWeightJournal, "node1")
+	require.False(ok)
+	_, ok = sc.GetJournalStep(Fuji, SetWeightJournal, "node2")
+	require.True(ok)
+	sc.ClearJournalStep(Fuji, SetWeightJournal, "node2")
+	require.Empty(sc.Journals)
+}
@@ -682,14 +682,23 @@ func GetFujiKeyOrLedger(prompt Prompter, goal string, keyDir string) (bool, stri
 	if !useStoredKey {
 		return true, "", nil
 	}
+	keyName, err := GetKeyName(prompt, goal, keyDir)
+	if err != nil {
+		return false, "", err
+	}
+	return false, keyName, nil
+}
+
+// GetKeyName prompts for the stored key to use to [goal]
+func GetKeyName(prompt Prompter, goal string, keyDir string) (string, error) {
 	keyName, err := captureKeyName(prompt, goal, keyDir)
 	if err != nil {
 		if errors.Is(err, errNoKeys) {
 			ux.Logger.PrintToUser("No private keys have been found. Create a new one with `avalanche key create`")
 		}
-		return false, "", err
+		return "", err
 	}
-	return false, keyName, nil
+	return keyName, nil
 }
 
 func captureKeyName(prompt Prompter, goal string, keyDir string) (string, error) {
@@ -86,7 +86,16 @@ func (d *LocalDeployer) DeployToLocalNetwork(chain string, chainGenesis []byte,
 	if err := d.StartServer(); err != nil {
 		return ids.Empty, ids.Empty, err
 	}
-	return d.doDeploy(chain, chainGenesis, genesisPath)
+	return d.doDeploy(chain, chainGenesis, genesisPath, ids.Empty)
+}
+
+// DeployChainToLocalSubnet deploys [chain] as an additional blockchain
+// into the already deployed local subnet [subnetID]
+func (d *LocalDeployer) DeployChainToLocalSubnet(chain string, chainGenesis []byte, genesisPath string, subnetID ids.ID) (ids.ID, ids.ID, error) {
+	if err := d.StartServer(); err != nil {
+		return ids.Empty, ids.Empty, err
+	}
+	return d.doDeploy(chain, chainGenesis, genesisPath, subnetID)
 }
 
 func getAssetID(wallet primary.Wallet, tokenName string, tokenSymbol string, maxSupply uint64) (ids.ID, error) {
@@ -324,11 +333,12 @@ func (d *LocalDeployer) BackendStartedHere() bool {
 //   - either starts a network from the default snapshot if not started,
 //     or restarts the already available network while preserving state
 //   - waits completion of operation
-//   - get from the network an available subnet ID to be used in blockchain creation
+//   - get from the network an available subnet ID to be used in blockchain creation,
+//     unless a [subnetID] is given
 //   - deploy a new blockchain for the given VM ID, genesis, and available subnet ID
 //   - waits completion of operation
 //   - show status
-func (d *LocalDeployer) doDeploy(chain string, chainGenesis []byte, genesisPath string) (ids.ID, ids.ID, error) {
+func (d *LocalDeployer) doDeploy(chain string, chainGenesis []byte, genesisPath string, subnetID ids.ID) (ids.ID, ids.ID, error) {
 	avalancheGoBinPath, err := d.SetupLocalEnv()
 	if err != nil {
 		return ids.Empty, ids.Empty, err
@@ -410,6 +420,12 @@ func (d *LocalDeployer) doDeploy(chain string, chainGenesis []byte, genesisPath
 		return ids.Empty, ids.Empty, errors.New("the network has not preloaded subnet IDs")
 	}
 	subnetIDStr := subnetIDs[numBlockchains%len(subnetIDs)]
+	if subnetID != ids.Empty {
+		if _, ok := clusterInfo.Subnets[subnetID.String()]; !ok {
+			return ids.Empty, ids.Empty, fmt.Errorf("subnet %s is not deployed on the local network", subnetID)
+		}
+		subnetIDStr = subnetID.String()
+	}
 
 	// if a chainConfig has been configured
 	var (
@@ -420,6 +436,10 @@ func (d *LocalDeployer) doDeploy(chain string, chainGenesis []byte, genesisPath
 		subnetConfig           string
 		subnetConfigFile       = filepath.Join(d.app.GetSubnetDir(), chain, constants.SubnetConfigFileName)
 	)
+	// the subnet config is shared by all of the subnet's chains
+	if sc.IsSubnetChain() {
+		subnetConfigFile = filepath.Join(d.app.GetSubnetDir(), sc.Subnet, constants.SubnetConfigFileName)
+	}
 	if _, err := os.Stat(chainConfigFile); err == nil {
 		// currently the ANR only accepts the file as a path, not its content
 		chainConfig = chainConfigFile
@@ -498,7 +518,7 @@ func (d *LocalDeployer) doDeploy(chain string, chainGenesis []byte, genesisPath
 	}
 
 	// we can safely ignore errors here as the subnets have already been generated
-	subnetID, _ := ids.FromString(subnetIDStr)
+	subnetID, _ = ids.FromString(subnetIDStr)
 	var blockchainID ids.ID
 	for _, info := range clusterInfo.CustomChains {
 		if info.VmId == chainVMID.String() {
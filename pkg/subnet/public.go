@@ -16,17 +16,21 @@ import (
 
 	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/key"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/txutils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanche-network-runner/utils"
 	"github.com/ava-labs/avalanchego/ids"
+	avago_constants "github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
 )
@@ -66,13 +70,47 @@ func (d *PublicDeployer) AddValidator(
 	startTime time.Time,
 	duration time.Duration,
 ) (bool, *txs.Tx, []string, error) {
-	wallet, err := d.loadWallet(subnetID)
+	tx, err := d.CreateAddValidatorTx(subnetAuthKeysStrs, subnetID, nodeID, weight, startTime, duration)
+	if err != nil {
+		return false, nil, nil, err
+	}
+
+	_, remainingSubnetAuthKeys, err := txutils.GetRemainingSigners(tx, controlKeys)
 	if err != nil {
 		return false, nil, nil, err
 	}
+	isFullySigned := len(remainingSubnetAuthKeys) == 0
+
+	if isFullySigned {
+		id, err := d.Commit(tx)
+		if err != nil {
+			return false, nil, nil, err
+		}
+		ux.Logger.PrintToUser("Transaction successful, transaction ID: %s", id)
+		return true, tx, nil, nil
+	}
+
+	ux.Logger.PrintToUser("Partial tx created")
+	return false, tx, remainingSubnetAuthKeys, nil
+}
+
+// CreateAddValidatorTx creates an add subnet validator tx, signed with the wallet
+// keys but not issued, so it can be committed later on
+func (d *PublicDeployer) CreateAddValidatorTx(
+	subnetAuthKeysStrs []string,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	weight uint64,
+	startTime time.Time,
+	duration time.Duration,
+) (*txs.Tx, error) {
+	wallet, err := d.loadWallet(subnetID)
+	if err != nil {
+		return nil, err
+	}
 	subnetAuthKeys, err := address.ParseToIDs(subnetAuthKeysStrs)
 	if err != nil {
-		return false, nil, nil, fmt.Errorf("failure parsing subnet auth keys: %w", err)
+		return nil, fmt.Errorf("failure parsing subnet auth keys: %w", err)
 	}
 	validator := &txs.SubnetValidator{
 		Validator: txs.Validator{
@@ -86,29 +124,88 @@ func (d *PublicDeployer) AddValidator(
 	if d.usingLedger {
 		ux.Logger.PrintToUser("*** Please sign SubnetValidator transaction on the ledger device *** ")
 	}
+	return d.createAddSubnetValidatorTx(subnetAuthKeys, validator, wallet)
+}
 
-	tx, err := d.createAddSubnetValidatorTx(subnetAuthKeys, validator, wallet)
+// CreateSetWeightTxs creates, without issuing them, a remove subnet validator tx
+// for [nodeID] and an add subnet validator tx adding it back with [weight] from
+// [startTime] for [duration]. Both are signed with the wallet keys. The add tx
+// spends the change of the remove tx, so it can only be committed after it
+func (d *PublicDeployer) CreateSetWeightTxs(
+	subnetAuthKeysStrs []string,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	weight uint64,
+	startTime time.Time,
+	duration time.Duration,
+) (*txs.Tx, *txs.Tx, error) {
+	ctx := context.Background()
+	api, err := d.network.Endpoint()
 	if err != nil {
-		return false, nil, nil, err
+		return nil, nil, err
 	}
-
-	_, remainingSubnetAuthKeys, err := txutils.GetRemainingSigners(tx, controlKeys)
+	subnetAuthKeys, err := address.ParseToIDs(subnetAuthKeysStrs)
 	if err != nil {
-		return false, nil, nil, err
+		return nil, nil, fmt.Errorf("failure parsing subnet auth keys: %w", err)
 	}
-	isFullySigned := len(remainingSubnetAuthKeys) == 0
+	// the wallet backend is kept to accept the remove tx locally, so that the
+	// add tx doesn't spend the same UTXOs
+	pCTX, _, utxos, err := primary.FetchState(ctx, api, d.kc.Addresses())
+	if err != nil {
+		return nil, nil, err
+	}
+	pClient := platformvm.NewClient(api)
+	subnetTxBytes, err := pClient.GetTx(ctx, subnetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	subnetTx, err := txs.Parse(txs.Codec, subnetTxBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	pBackend := p.NewBackend(pCTX, primary.NewChainUTXOs(avago_constants.PlatformChainID, utxos), map[ids.ID]*txs.Tx{subnetID: subnetTx})
+	wallet := primary.NewWallet(
+		p.NewWallet(p.NewBuilder(d.kc.Addresses(), pBackend), p.NewSigner(d.kc, pBackend), pClient, pBackend),
+		nil,
+	)
 
-	if isFullySigned {
-		id, err := d.Commit(tx)
-		if err != nil {
-			return false, nil, nil, err
-		}
-		ux.Logger.PrintToUser("Transaction successful, transaction ID: %s", id)
-		return true, nil, nil, nil
+	if d.usingLedger {
+		ux.Logger.PrintToUser("*** Please sign tx hash on the ledger device *** ")
+	}
+	removeTx, err := d.createRemoveValidatorTX(subnetAuthKeys, nodeID, subnetID, wallet)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := pBackend.AcceptTx(ctx, removeTx); err != nil {
+		return nil, nil, err
+	}
+	validator := &txs.SubnetValidator{
+		Validator: txs.Validator{
+			NodeID: nodeID,
+			Start:  uint64(startTime.Unix()),
+			End:    uint64(startTime.Add(duration).Unix()),
+			Wght:   weight,
+		},
+		Subnet: subnetID,
 	}
+	if d.usingLedger {
+		ux.Logger.PrintToUser("*** Please sign SubnetValidator transaction on the ledger device *** ")
+	}
+	addTx, err := d.createAddSubnetValidatorTx(subnetAuthKeys, validator, wallet)
+	if err != nil {
+		return nil, nil, err
+	}
+	return removeTx, addTx, nil
+}
 
-	ux.Logger.PrintToUser("Partial tx created")
-	return false, tx, remainingSubnetAuthKeys, nil
+// HoldsSubnetAuthKeys tells if the wallet holds all [subnetAuthKeysStrs], so
+// that the txs it creates for the subnet are fully signed
+func (d *PublicDeployer) HoldsSubnetAuthKeys(subnetAuthKeysStrs []string) (bool, error) {
+	subnetAuthKeys, err := address.ParseToIDs(subnetAuthKeysStrs)
+	if err != nil {
+		return false, fmt.Errorf("failure parsing subnet auth keys: %w", err)
+	}
+	return len(d.getSubnetAuthAddressesInWallet(subnetAuthKeys)) == len(subnetAuthKeys), nil
 }
 
 // AddValidatorPrimaryNetwork adds node as Primary Network Validator
@@ -317,7 +414,7 @@ func (d *PublicDeployer) RemoveValidator(
 			return false, nil, nil, err
 		}
 		ux.Logger.PrintToUser("Transaction successful, transaction ID: %s", id)
-		return true, nil, nil, nil
+		return true, tx, nil, nil
 	}
 
 	ux.Logger.PrintToUser("Partial tx created")
@@ -446,6 +543,144 @@ func (d *PublicDeployer) DeployBlockchain(
 	return isFullySigned, id, tx, remainingSubnetAuthKeys, nil
 }
 
+// DeployDryRun is the outcome of checking a subnet deploy without issuing its txs
+type DeployDryRun struct {
+	PayerAddresses  []string
+	Balance         uint64
+	CreateSubnetFee uint64
+	CreateChainFee  uint64
+	NumChains       int
+	// reasons for which the deploy would fail
+	Errors []string
+}
+
+// TotalFee returns the fees to be paid by the deploy
+func (r *DeployDryRun) TotalFee() uint64 {
+	return r.CreateSubnetFee + uint64(r.NumChains)*r.CreateChainFee
+}
+
+// checks a deploy of [chains] without issuing any tx
+//   - if [subnetID] is empty, builds the create subnet tx for the given [controlKeys] and [threshold],
+//     and uses its ID as the subnet ID of the chains
+//   - builds the create blockchain tx of each chain, using [subnetAuthKeysStrs]
+//   - gets the fees for the txs, and checks them against the wallet P-Chain balance
+func (d *PublicDeployer) DeployDryRun(
+	controlKeys []string,
+	threshold uint32,
+	subnetAuthKeysStrs []string,
+	subnetID ids.ID,
+	chains []string,
+	geneses [][]byte,
+) (*DeployDryRun, error) {
+	ctx := context.Background()
+	api, err := d.network.Endpoint()
+	if err != nil {
+		return nil, err
+	}
+	subnetAuthKeys, err := address.ParseToIDs(subnetAuthKeysStrs)
+	if err != nil {
+		return nil, fmt.Errorf("failure parsing subnet auth keys: %w", err)
+	}
+	// the wallet backend is kept to accept the create subnet tx locally, so that the
+	// chain txs can be built against it
+	pCTX, _, utxos, err := primary.FetchState(ctx, api, d.kc.Addresses())
+	if err != nil {
+		return nil, err
+	}
+	subnetTxs := map[ids.ID]*txs.Tx{}
+	if subnetID != ids.Empty {
+		subnetTxBytes, err := platformvm.NewClient(api).GetTx(ctx, subnetID)
+		if err != nil {
+			return nil, err
+		}
+		subnetTx, err := txs.Parse(txs.Codec, subnetTxBytes)
+		if err != nil {
+			return nil, err
+		}
+		subnetTxs[subnetID] = subnetTx
+	}
+	pBackend := p.NewBackend(pCTX, primary.NewChainUTXOs(avago_constants.PlatformChainID, utxos), subnetTxs)
+	pBuilder := p.NewBuilder(d.kc.Addresses(), pBackend)
+
+	result := &DeployDryRun{
+		CreateChainFee: pCTX.CreateBlockchainTxFee(),
+		NumChains:      len(chains),
+	}
+	networkID, err := d.network.NetworkID()
+	if err != nil {
+		return nil, err
+	}
+	hrp := key.GetHRP(networkID)
+	for _, addr := range d.kc.Addresses().List() {
+		addrStr, err := address.Format("P", hrp, addr[:])
+		if err != nil {
+			return nil, err
+		}
+		result.PayerAddresses = append(result.PayerAddresses, addrStr)
+	}
+	balances, err := pBuilder.GetBalance()
+	if err != nil {
+		return nil, err
+	}
+	result.Balance = balances[pCTX.AVAXAssetID()]
+
+	if subnetID == ids.Empty {
+		result.CreateSubnetFee = pCTX.CreateSubnetTxFee()
+		addrs, err := address.ParseToIDs(controlKeys)
+		if err != nil {
+			return nil, fmt.Errorf("failure parsing control keys: %w", err)
+		}
+		owners := &secp256k1fx.OutputOwners{
+			Addrs:     addrs,
+			Threshold: threshold,
+			Locktime:  0,
+		}
+		utx, err := pBuilder.NewCreateSubnetTx(owners)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("create subnet tx: %s", err))
+			// the chain txs can't be built without the subnet
+			return checkDryRunBalance(result), nil
+		}
+		subnetTx, err := txs.NewSigned(utx, txs.Codec, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := pBackend.AcceptTx(ctx, subnetTx); err != nil {
+			return nil, err
+		}
+		subnetID = subnetTx.ID()
+	}
+	for i, chain := range chains {
+		vmID, err := utils.VMID(chain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create VM ID from %s: %w", chain, err)
+		}
+		if _, err := pBuilder.NewCreateChainTx(
+			subnetID,
+			geneses[i],
+			vmID,
+			[]ids.ID{},
+			chain,
+			d.getMultisigTxOptions(subnetAuthKeys)...,
+		); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("create blockchain tx for %s: %s", chain, err))
+		}
+	}
+	return checkDryRunBalance(result), nil
+}
+
+// checkDryRunBalance adds an error to [result] if the wallet balance doesn't cover its fees
+func checkDryRunBalance(result *DeployDryRun) *DeployDryRun {
+	if result.Balance < result.TotalFee() {
+		result.Errors = append(result.Errors, fmt.Sprintf(
+			"insufficient P-Chain balance: %.9f AVAX needed, %.9f AVAX available",
+			float64(result.TotalFee())/float64(units.Avax),
+			float64(result.Balance)/float64(units.Avax),
+		))
+	}
+	return result
+}
+
 func (d *PublicDeployer) Commit(
 	tx *txs.Tx,
 ) (ids.ID, error) {
@@ -490,17 +725,9 @@ func (d *PublicDeployer) Sign(
 func (d *PublicDeployer) loadWallet(preloadTxs ...ids.ID) (primary.Wallet, error) {
 	ctx := context.Background()
 
-	var api string
-	switch d.network {
-	case models.Fuji:
-		api = constants.FujiAPIEndpoint
-	case models.Mainnet:
-		api = constants.MainnetAPIEndpoint
-	case models.Local:
-		// used for E2E testing of public related paths
-		api = constants.LocalAPIEndpoint
-	default:
-		return nil, fmt.Errorf("unsupported public network")
+	api, err := d.network.Endpoint()
+	if err != nil {
+		return nil, err
 	}
 
 	wallet, err := primary.NewWalletWithTxs(ctx, api, d.kc, preloadTxs...)
@@ -754,6 +981,9 @@ func IsSubnetValidator(subnetID ids.ID, nodeID ids.NodeID, network models.Networ
 		apiURL = constants.MainnetAPIEndpoint
 	case models.Fuji:
 		apiURL = constants.FujiAPIEndpoint
+	case models.Local:
+		// used for E2E testing of public related paths
+		apiURL = constants.LocalAPIEndpoint
 	default:
 		return false, fmt.Errorf("invalid network: %s", network)
 	}
@@ -4,14 +4,31 @@ package utils
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"os"
 	"os/exec"
+	"unicode"
 )
 
+var ErrIllegalNameCharacter = errors.New(
+	"illegal name character: only letters, no special characters allowed")
+
 func SetupRealtimeCLIOutput(cmd *exec.Cmd) {
 	var stdBuffer bytes.Buffer
 	mw := io.MultiWriter(os.Stdout, &stdBuffer)
 	cmd.Stdout = mw
 	cmd.Stderr = mw
 }
+
+// ValidateSubnetName checks that [name] is a valid subnet name, which is also
+// what keeps it from escaping the subnets dir when used as a path
+func ValidateSubnetName(name string) error {
+	// this is currently exactly the same code as in avalanchego/vms/platformvm/create_chain_tx.go
+	for _, r := range name {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsNumber(r) || r == ' ') {
+			return ErrIllegalNameCharacter
+		}
+	}
+	return nil
+}
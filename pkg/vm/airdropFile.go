@@ -0,0 +1,175 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// airdropUnits are the units accepted after an airdrop amount. Amounts
+// without unit are in whole tokens, as in the airdrop wizard
+var airdropUnits = map[string]*big.Int{
+	"wei":   big.NewInt(params.Wei),
+	"gwei":  big.NewInt(params.GWei),
+	"ether": big.NewInt(params.Ether),
+}
+
+type airdropEntry struct {
+	Address string `json:"address"`
+	Amount  string `json:"amount"`
+}
+
+// LoadAirdropFile reads the genesis allocations of [path]. CSV files have an
+// address,amount row per allocation, with an optional header row. JSON files
+// have a list of {"address": ..., "amount": ...} objects. Amounts are in whole
+// tokens, or followed by one of the units wei, gwei and ether, as in "1.5 ether".
+// Addresses must be checksummed, and can't be repeated
+func LoadAirdropFile(path string) (core.GenesisAlloc, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var entries []airdropEntry
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		entries, err = readAirdropCSV(file)
+	case ".json":
+		err = json.NewDecoder(file).Decode(&entries)
+	default:
+		return nil, fmt.Errorf("unsupported airdrop file %s, expected a .csv or .json file", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid airdrop file %s: %w", path, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("airdrop file %s has no allocations", path)
+	}
+
+	allocation := core.GenesisAlloc{}
+	for i, entry := range entries {
+		address, err := parseAirdropAddress(entry.Address)
+		if err != nil {
+			return nil, fmt.Errorf("allocation %d: %w", i+1, err)
+		}
+		if _, ok := allocation[address]; ok {
+			return nil, fmt.Errorf("allocation %d: duplicate address %s", i+1, address.Hex())
+		}
+		amount, err := parseAirdropAmount(entry.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("allocation %d: %w", i+1, err)
+		}
+		allocation[address] = core.GenesisAccount{Balance: amount}
+	}
+	return allocation, nil
+}
+
+func readAirdropCSV(r io.Reader) ([]airdropEntry, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = 2
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 0 && strings.EqualFold(strings.TrimSpace(records[0][0]), "address") {
+		records = records[1:]
+	}
+	entries := make([]airdropEntry, 0, len(records))
+	for _, record := range records {
+		entries = append(entries, airdropEntry{Address: record[0], Amount: record[1]})
+	}
+	return entries, nil
+}
+
+func parseAirdropAddress(addressStr string) (common.Address, error) {
+	addressStr = strings.TrimSpace(addressStr)
+	if !common.IsHexAddress(addressStr) {
+		return common.Address{}, fmt.Errorf("invalid address %q", addressStr)
+	}
+	if !evm.IsChecksummedAddress(addressStr) {
+		return common.Address{}, fmt.Errorf("address %s is not checksummed, its checksummed form is %s",
+			addressStr, common.HexToAddress(addressStr).Hex())
+	}
+	return common.HexToAddress(addressStr), nil
+}
+
+// parseAirdropAmount converts [amountStr], in whole tokens or followed by a unit,
+// into wei
+func parseAirdropAmount(amountStr string) (*big.Int, error) {
+	fields := strings.Fields(amountStr)
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, fmt.Errorf("invalid amount %q", amountStr)
+	}
+	multiplier := oneAvax
+	if len(fields) == 2 {
+		var ok bool
+		multiplier, ok = airdropUnits[strings.ToLower(fields[1])]
+		if !ok {
+			return nil, fmt.Errorf("invalid unit %q in amount %q, expected one of wei, gwei, ether", fields[1], amountStr)
+		}
+	}
+	value, ok := new(big.Rat).SetString(fields[0])
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", amountStr)
+	}
+	value.Mul(value, new(big.Rat).SetInt(multiplier))
+	if !value.IsInt() {
+		return nil, fmt.Errorf("amount %q is not a whole number of wei", amountStr)
+	}
+	if value.Sign() <= 0 {
+		return nil, fmt.Errorf("amount %q must be positive", amountStr)
+	}
+	return new(big.Int).Set(value.Num()), nil
+}
+
+// getTotalSupply returns the sum of the balances of [allocation]
+func getTotalSupply(allocation core.GenesisAlloc) *big.Int {
+	total := big.NewInt(0)
+	for _, account := range allocation {
+		if account.Balance != nil {
+			total.Add(total, account.Balance)
+		}
+	}
+	return total
+}
+
+// getEVMAllocationFromFile loads the allocations of the airdrop file [path] and
+// prints the resulting total supply
+func getEVMAllocationFromFile(path string) (core.GenesisAlloc, error) {
+	allocation, err := LoadAirdropFile(path)
+	if err != nil {
+		return nil, err
+	}
+	totalSupply := getTotalSupply(allocation)
+	wholeTokens, remainder := new(big.Int).QuoRem(totalSupply, oneAvax, new(big.Int))
+	ux.Logger.PrintToUser("Loaded %d allocations from %s, for a total supply of %s tokens (%s wei)",
+		len(allocation), path, formatTokens(wholeTokens, remainder), totalSupply)
+	return allocation, nil
+}
+
+// formatTokens formats an amount of [wholeTokens] plus [remainder] wei
+func formatTokens(wholeTokens, remainder *big.Int) string {
+	if remainder.Sign() == 0 {
+		return wholeTokens.String()
+	}
+	decimals := remainder.String()
+	decimals = strings.Repeat("0", 18-len(decimals)) + decimals
+	decimals = strings.TrimRight(decimals, "0")
+	return wholeTokens.String() + "." + decimals
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const testAirdropAddress2 = "0xb794F5eA0ba39494cE839613fffBA74279579268"
+
+func writeAirdropFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadAirdropFile(t *testing.T) {
+	require := setupTest(t)
+
+	csvPath := writeAirdropFile(t, "allocations.csv", `address,amount
+# team
+`+testAirdropAddress.Hex()+`, 1000
+`+testAirdropAddress2+`, 1.5 ether
+`)
+	allocation, err := LoadAirdropFile(csvPath)
+	require.NoError(err)
+	require.Len(allocation, 2)
+	expected, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	require.Equal(expected, allocation[testAirdropAddress].Balance)
+	require.Equal(big.NewInt(1_500_000_000_000_000_000), allocation[common.HexToAddress(testAirdropAddress2)].Balance)
+
+	jsonPath := writeAirdropFile(t, "allocations.json", `[
+		{"address": "`+testAirdropAddress.Hex()+`", "amount": "25 wei"},
+		{"address": "`+testAirdropAddress2+`", "amount": "3 gwei"}
+	]`)
+	allocation, err = LoadAirdropFile(jsonPath)
+	require.NoError(err)
+	require.Equal(big.NewInt(25), allocation[testAirdropAddress].Balance)
+	require.Equal(big.NewInt(3_000_000_000), allocation[common.HexToAddress(testAirdropAddress2)].Balance)
+	require.Equal(big.NewInt(3_000_000_025), getTotalSupply(allocation))
+
+	// the allocations of a file keep the tx allow list admin check working
+	allowListCfg := txallowlist.NewConfig(nil, []common.Address{testAirdropAddress}, nil)
+	require.NoError(ensureAdminsHaveBalance(allowListCfg.AdminAddresses, allocation))
+	require.Error(ensureAdminsHaveBalance([]common.Address{{}}, allocation))
+}
+
+func TestLoadAirdropFileErrors(t *testing.T) {
+	require := setupTest(t)
+
+	tests := []struct {
+		name     string
+		content  string
+		errorMsg string
+	}{
+		{
+			name:     "not checksummed",
+			content:  "0xb794f5ea0ba39494ce839613fffba74279579268,1",
+			errorMsg: "is not checksummed, its checksummed form is " + testAirdropAddress2,
+		},
+		{
+			name:     "invalid address",
+			content:  "0x1234,1",
+			errorMsg: "invalid address",
+		},
+		{
+			name:     "duplicate",
+			content:  testAirdropAddress2 + ",1\n" + testAirdropAddress2 + ",2",
+			errorMsg: "allocation 2: duplicate address",
+		},
+		{
+			name:     "unknown unit",
+			content:  testAirdropAddress2 + ",1 avax",
+			errorMsg: "invalid unit",
+		},
+		{
+			name:     "fractional wei",
+			content:  testAirdropAddress2 + ",1.5 wei",
+			errorMsg: "not a whole number of wei",
+		},
+		{
+			name:     "zero amount",
+			content:  testAirdropAddress2 + ",0",
+			errorMsg: "must be positive",
+		},
+		{
+			name:     "empty",
+			content:  "address,amount\n",
+			errorMsg: "has no allocations",
+		},
+	}
+	for _, tt := range tests {
+		_, err := LoadAirdropFile(writeAirdropFile(t, "allocations.csv", tt.content))
+		require.ErrorContains(err, tt.errorMsg, tt.name)
+	}
+
+	_, err := LoadAirdropFile(writeAirdropFile(t, "allocations.txt", ""))
+	require.ErrorContains(err, "expected a .csv or .json file")
+}
+
+func TestFormatTokens(t *testing.T) {
+	require := setupTest(t)
+	require.Equal("12", formatTokens(big.NewInt(12), big.NewInt(0)))
+	require.Equal("12.5", formatTokens(big.NewInt(12), big.NewInt(500_000_000_000_000_000)))
+	require.Equal("0.000000000000000025", formatTokens(big.NewInt(0), big.NewInt(25)))
+}
@@ -21,7 +21,13 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
-func CreateEvmSubnetConfig(app *application.Avalanche, subnetName string, genesisPath string, subnetEVMVersion string) ([]byte, *models.Sidecar, error) {
+func CreateEvmSubnetConfig(
+	app *application.Avalanche,
+	subnetName string,
+	genesisPath string,
+	airdropFile string,
+	subnetEVMVersion string,
+) ([]byte, *models.Sidecar, error) {
 	var (
 		genesisBytes []byte
 		sc           *models.Sidecar
@@ -29,11 +35,14 @@ func CreateEvmSubnetConfig(app *application.Avalanche, subnetName string, genesi
 	)
 
 	if genesisPath == "" {
-		genesisBytes, sc, err = createEvmGenesis(app, subnetName, subnetEVMVersion)
+		genesisBytes, sc, err = createEvmGenesis(app, subnetName, airdropFile, subnetEVMVersion)
 		if err != nil {
 			return nil, &models.Sidecar{}, err
 		}
 	} else {
+		if airdropFile != "" {
+			return nil, &models.Sidecar{}, errors.New("an airdrop file can't be used along with an existing genesis")
+		}
 		ux.Logger.PrintToUser("Importing genesis")
 		genesisBytes, err = os.ReadFile(genesisPath)
 		if err != nil {
@@ -66,6 +75,7 @@ func CreateEvmSubnetConfig(app *application.Avalanche, subnetName string, genesi
 func createEvmGenesis(
 	app *application.Avalanche,
 	subnetName string,
+	airdropFile string,
 	subnetEVMVersion string,
 ) ([]byte, *models.Sidecar, error) {
 	ux.Logger.PrintToUser("creating subnet %s", subnetName)
@@ -102,7 +112,12 @@ func createEvmGenesis(
 		case feeState:
 			*conf, direction, err = GetFeeConfig(*conf, app)
 		case airdropState:
-			allocation, direction, err = getEVMAllocation(app)
+			if airdropFile != "" {
+				allocation, err = getEVMAllocationFromFile(airdropFile)
+				direction = statemachine.Forward
+			} else {
+				allocation, direction, err = getEVMAllocation(app)
+			}
 		case precompilesState:
 			*conf, direction, err = getPrecompiles(*conf, app)
 		default:
@@ -175,7 +190,7 @@ func ensureAdminsHaveBalance(admins []common.Address, alloc core.GenesisAlloc) e
 		// we can break at the first admin who has a non-zero balance
 		if bal, ok := alloc[admin]; ok &&
 			bal.Balance != nil &&
-			bal.Balance.Uint64() > uint64(0) {
+			bal.Balance.Sign() > 0 {
 			return nil
 		}
 	}
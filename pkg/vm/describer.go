@@ -0,0 +1,238 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/olekukonko/tablewriter"
+)
+
+// common locations of the chain ID in genesis files
+var defaultChainIDPaths = []string{"config.chainId", "config.chainID", "chainId", "chainID"}
+
+// Describer prints a human readable summary of the genesis of a VM
+type Describer interface {
+	// GetChainID returns the chain ID set in [genesis], or "" if it has none
+	GetChainID(genesis []byte) (string, error)
+	// Describe prints the summary of [genesis], which belongs to [sc]
+	Describe(sc models.Sidecar, genesis []byte) error
+}
+
+// GenesisSchema tells how to describe the genesis of a custom VM.
+// Paths are dot separated keys into the genesis JSON, with numbers
+// used as indexes into arrays (e.g. "config.chainId" or "alloc.0.balance")
+type GenesisSchema struct {
+	ChainID string               `json:"chainID"`
+	Fields  []GenesisSchemaField `json:"fields"`
+}
+
+type GenesisSchemaField struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// GetCustomDescriber returns the describer provided for the custom VM of [sc].
+// A describe hook binary takes precedence over a genesis schema. If none of
+// them were provided, the genesis is described as a generic JSON tree
+func GetCustomDescriber(app *application.Avalanche, sc models.Sidecar) (Describer, error) {
+	hookPath := app.GetDescribeHookPath(sc.Name)
+	if _, err := os.Stat(hookPath); err == nil {
+		return HookDescriber{Path: hookPath}, nil
+	}
+	schemaBytes, err := os.ReadFile(app.GetGenesisSchemaPath(sc.Name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return GenericDescriber{}, nil
+		}
+		return nil, err
+	}
+	var schema GenesisSchema
+	if err := json.Unmarshal(schemaBytes, &schema); err != nil {
+		return nil, fmt.Errorf("invalid genesis schema for %s: %w", sc.Name, err)
+	}
+	return SchemaDescriber{Schema: schema}, nil
+}
+
+// GenericDescriber prints any JSON genesis as a tree
+type GenericDescriber struct{}
+
+func (GenericDescriber) GetChainID(genesis []byte) (string, error) {
+	tree, err := parseGenesisTree(genesis)
+	if err != nil {
+		return "", err
+	}
+	for _, path := range defaultChainIDPaths {
+		if value, ok := lookupPath(tree, path); ok {
+			return formatValue(value), nil
+		}
+	}
+	return "", nil
+}
+
+func (GenericDescriber) Describe(_ models.Sidecar, genesis []byte) error {
+	tree, err := parseGenesisTree(genesis)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Genesis:")
+	for _, line := range formatTree(tree, "  ") {
+		ux.Logger.PrintToUser(line)
+	}
+	return nil
+}
+
+// SchemaDescriber prints the genesis fields listed in a schema
+type SchemaDescriber struct {
+	Schema GenesisSchema
+}
+
+func (d SchemaDescriber) GetChainID(genesis []byte) (string, error) {
+	if d.Schema.ChainID == "" {
+		return GenericDescriber{}.GetChainID(genesis)
+	}
+	tree, err := parseGenesisTree(genesis)
+	if err != nil {
+		return "", err
+	}
+	value, ok := lookupPath(tree, d.Schema.ChainID)
+	if !ok {
+		return "", fmt.Errorf("chain ID path %q not found in genesis", d.Schema.ChainID)
+	}
+	return formatValue(value), nil
+}
+
+func (d SchemaDescriber) Describe(_ models.Sidecar, genesis []byte) error {
+	tree, err := parseGenesisTree(genesis)
+	if err != nil {
+		return err
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Genesis Field", "Value"})
+	table.SetRowLine(true)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	for _, field := range d.Schema.Fields {
+		value := "-"
+		if v, ok := lookupPath(tree, field.Path); ok {
+			value = formatValue(v)
+		}
+		table.Append([]string{field.Name, value})
+	}
+	table.Render()
+	return nil
+}
+
+// HookDescriber delegates the description to an external binary, which gets
+// the subnet name as argument and the genesis on its standard input
+type HookDescriber struct {
+	Path string
+}
+
+func (HookDescriber) GetChainID(genesis []byte) (string, error) {
+	return GenericDescriber{}.GetChainID(genesis)
+}
+
+func (d HookDescriber) Describe(sc models.Sidecar, genesis []byte) error {
+	cmd := exec.Command(d.Path, sc.Name) //nolint:gosec
+	cmd.Stdin = bytes.NewReader(genesis)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("describe hook %s failed: %w", d.Path, err)
+	}
+	return nil
+}
+
+func parseGenesisTree(genesis []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(genesis))
+	// keep big numbers, as balances and chain IDs, as they are
+	decoder.UseNumber()
+	var tree interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, fmt.Errorf("genesis is not valid JSON: %w", err)
+	}
+	return tree, nil
+}
+
+// lookupPath returns the value found at the dot separated [path] of [tree]
+func lookupPath(tree interface{}, path string) (interface{}, bool) {
+	current := tree
+	for _, key := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		valueBytes, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(valueBytes)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// formatTree renders [tree] as indented lines, with map keys sorted
+func formatTree(tree interface{}, indent string) []string {
+	lines := []string{}
+	switch node := tree.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(node))
+		for key := range node {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			lines = append(lines, formatTreeEntry(key, node[key], indent)...)
+		}
+	case []interface{}:
+		for i, value := range node {
+			lines = append(lines, formatTreeEntry(strconv.Itoa(i), value, indent)...)
+		}
+	default:
+		lines = append(lines, indent+formatValue(node))
+	}
+	return lines
+}
+
+func formatTreeEntry(key string, value interface{}, indent string) []string {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return append([]string{indent + key + ":"}, formatTree(value, indent+"  ")...)
+	default:
+		return []string{fmt.Sprintf("%s%s: %s", indent, key, formatValue(value))}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const testCustomGenesis = `{
+	"config": {"chainId": 12345678901234567890},
+	"owners": ["alice", "bob"],
+	"token": {"symbol": "TST"}
+}`
+
+func TestGenericDescriber(t *testing.T) {
+	require := setupTest(t)
+
+	chainID, err := GenericDescriber{}.GetChainID([]byte(testCustomGenesis))
+	require.NoError(err)
+	require.Equal("12345678901234567890", chainID)
+
+	chainID, err = GenericDescriber{}.GetChainID([]byte(`{"foo": "bar"}`))
+	require.NoError(err)
+	require.Empty(chainID)
+
+	_, err = GenericDescriber{}.GetChainID([]byte("not json"))
+	require.Error(err)
+
+	tree, err := parseGenesisTree([]byte(testCustomGenesis))
+	require.NoError(err)
+	require.Equal([]string{
+		"config:",
+		"  chainId: 12345678901234567890",
+		"owners:",
+		"  0: alice",
+		"  1: bob",
+		"token:",
+		"  symbol: TST",
+	}, formatTree(tree, ""))
+}
+
+func TestSchemaDescriber(t *testing.T) {
+	require := setupTest(t)
+
+	tree, err := parseGenesisTree([]byte(testCustomGenesis))
+	require.NoError(err)
+	value, ok := lookupPath(tree, "owners.1")
+	require.True(ok)
+	require.Equal("bob", formatValue(value))
+	_, ok = lookupPath(tree, "owners.2")
+	require.False(ok)
+	value, ok = lookupPath(tree, "token")
+	require.True(ok)
+	require.Equal(`{"symbol":"TST"}`, formatValue(value))
+
+	describer := SchemaDescriber{Schema: GenesisSchema{ChainID: "token.symbol"}}
+	chainID, err := describer.GetChainID([]byte(testCustomGenesis))
+	require.NoError(err)
+	require.Equal("TST", chainID)
+
+	describer = SchemaDescriber{Schema: GenesisSchema{ChainID: "missing"}}
+	_, err = describer.GetChainID([]byte(testCustomGenesis))
+	require.Error(err)
+}
+
+func TestGetCustomDescriber(t *testing.T) {
+	require := setupTest(t)
+	app := application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, nil, nil)
+	sc := models.Sidecar{Name: "custom", VM: models.CustomVM}
+	require.NoError(os.MkdirAll(filepath.Join(app.GetSubnetDir(), sc.Name), 0o755))
+
+	describer, err := GetCustomDescriber(app, sc)
+	require.NoError(err)
+	require.IsType(GenericDescriber{}, describer)
+
+	schema := `{"chainID": "config.chainId", "fields": [{"name": "Token", "path": "token.symbol"}]}`
+	require.NoError(os.WriteFile(app.GetGenesisSchemaPath(sc.Name), []byte(schema), 0o600))
+	describer, err = GetCustomDescriber(app, sc)
+	require.NoError(err)
+	require.Equal(SchemaDescriber{Schema: GenesisSchema{
+		ChainID: "config.chainId",
+		Fields:  []GenesisSchemaField{{Name: "Token", Path: "token.symbol"}},
+	}}, describer)
+
+	require.NoError(os.WriteFile(app.GetDescribeHookPath(sc.Name), []byte("#!/bin/sh\n"), 0o700))
+	describer, err = GetCustomDescriber(app, sc)
+	require.NoError(err)
+	require.Equal(HookDescriber{Path: app.GetDescribeHookPath(sc.Name)}, describer)
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package vm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/statemachine"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+)
+
+// stages of the Subnet-EVM genesis creation that can be edited afterwards
+const (
+	ChainIDStage     = "chain-id"
+	TokenStage       = "token"
+	FeeStage         = "fee"
+	AirdropStage     = "airdrop"
+	PrecompilesStage = "precompiles"
+)
+
+var ErrGenesisEditCancelled = errors.New("genesis edit cancelled")
+
+// GetEditableEvmGenesisStages returns the stages of the Subnet-EVM creation
+// wizard that can be re-entered on an existing genesis
+func GetEditableEvmGenesisStages() []string {
+	return []string{ChainIDStage, TokenStage, FeeStage, AirdropStage, PrecompilesStage}
+}
+
+// EditEvmGenesis re-runs the creation wizard [stage] on the existing genesis
+// of [sc], verifies the result and returns the new genesis bytes.
+// The sidecar is updated in place when the stage changes its data (chain ID, token name).
+// If [airdropFile] is set, the airdrop stage takes the allocations from it.
+func EditEvmGenesis(app *application.Avalanche, sc *models.Sidecar, stage string, airdropFile string) ([]byte, error) {
+	genesis, err := app.LoadEvmGenesis(sc.Name)
+	if err != nil {
+		return nil, err
+	}
+	if genesis.Config == nil {
+		return nil, errors.New("genesis has no chain config")
+	}
+	conf := *genesis.Config
+	if conf.GenesisPrecompiles == nil {
+		conf.GenesisPrecompiles = map[string]precompileconfig.Config{}
+	}
+
+	direction := statemachine.Forward
+	switch stage {
+	case ChainIDStage:
+		conf.ChainID, err = getChainID(app)
+		if err == nil {
+			sc.ChainID = conf.ChainID.String()
+		}
+	case TokenStage:
+		sc.TokenName, err = getTokenName(app)
+	case FeeStage:
+		conf, direction, err = GetFeeConfig(conf, app)
+	case AirdropStage:
+		var allocation core.GenesisAlloc
+		if airdropFile != "" {
+			allocation, err = getEVMAllocationFromFile(airdropFile)
+		} else {
+			allocation, direction, err = getEVMAllocation(app)
+		}
+		if err == nil && direction == statemachine.Forward {
+			genesis.Alloc = allocation
+		}
+	case PrecompilesStage:
+		conf, direction, err = editPrecompiles(conf, app)
+	default:
+		return nil, fmt.Errorf("invalid genesis stage %q", stage)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if direction != statemachine.Forward {
+		return nil, ErrGenesisEditCancelled
+	}
+
+	if conf.GenesisPrecompiles[txallowlist.ConfigKey] != nil {
+		allowListCfg, ok := conf.GenesisPrecompiles[txallowlist.ConfigKey].(*txallowlist.Config)
+		if !ok {
+			return nil, fmt.Errorf("expected config of type txallowlist.AllowListConfig, but got %T", allowListCfg)
+		}
+		if err := ensureAdminsHaveBalance(allowListCfg.AdminAddresses, genesis.Alloc); err != nil {
+			return nil, err
+		}
+	}
+
+	genesis.Config = &conf
+	genesis.GasLimit = conf.FeeConfig.GasLimit.Uint64()
+
+	if err := genesis.Verify(); err != nil {
+		return nil, err
+	}
+
+	jsonBytes, err := genesis.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var prettyJSON bytes.Buffer
+	if err := json.Indent(&prettyJSON, jsonBytes, "", "    "); err != nil {
+		return nil, err
+	}
+	return prettyJSON.Bytes(), nil
+}
+
+func editPrecompiles(conf params.ChainConfig, app *application.Avalanche) (
+	params.ChainConfig,
+	statemachine.StateDirection,
+	error,
+) {
+	if len(conf.GenesisPrecompiles) > 0 {
+		const (
+			keepPrecompiles  = "Keep the current precompiles, and add or replace some"
+			resetPrecompiles = "Remove the current precompiles, and start over"
+		)
+		ux.Logger.PrintToUser("The genesis currently enables %d precompile(s)", len(conf.GenesisPrecompiles))
+		choice, err := app.Prompt.CaptureList(
+			"How would you like to edit the precompiles?",
+			[]string{keepPrecompiles, resetPrecompiles, prompts.Cancel},
+		)
+		if err != nil {
+			return conf, statemachine.Stop, err
+		}
+		switch choice {
+		case prompts.Cancel:
+			return conf, statemachine.Backward, nil
+		case resetPrecompiles:
+			conf.GenesisPrecompiles = map[string]precompileconfig.Config{}
+		}
+	}
+	return getPrecompiles(conf, app)
+}
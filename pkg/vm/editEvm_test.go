@@ -0,0 +1,44 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package vm
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/internal/mocks"
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_EditEvmGenesis(t *testing.T) {
+	require := setupTest(t)
+	app := application.New()
+	mockPrompt := &mocks.Prompter{}
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, mockPrompt, nil)
+
+	const subnetName = "testSubnet"
+	require.NoError(app.CopyGenesisFile("../../tests/e2e/assets/test_subnet_evm_genesis.json", subnetName))
+	sc := &models.Sidecar{Name: subnetName, Subnet: subnetName, VM: models.SubnetEvm, TokenName: "OLD", ChainID: "99999"}
+
+	mockPrompt.On("CapturePositiveBigInt", mock.Anything).Return(big.NewInt(4242), nil)
+	mockPrompt.On("CaptureString", mock.Anything).Return(testToken, nil)
+
+	genesisBytes, err := EditEvmGenesis(app, sc, ChainIDStage, "")
+	require.NoError(err)
+	var genesis core.Genesis
+	require.NoError(json.Unmarshal(genesisBytes, &genesis))
+	require.Equal(big.NewInt(4242), genesis.Config.ChainID)
+	require.Equal("4242", sc.ChainID)
+
+	_, err = EditEvmGenesis(app, sc, TokenStage, "")
+	require.NoError(err)
+	require.Equal(testToken, sc.TokenName)
+
+	_, err = EditEvmGenesis(app, sc, "invalid", "")
+	require.ErrorContains(err, "invalid genesis stage")
+}
@@ -4,6 +4,10 @@
 package vm
 
 import (
+	"fmt"
+	"math/big"
+	"strings"
+
 	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/statemachine"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
@@ -11,6 +15,36 @@ import (
 	"github.com/ava-labs/subnet-evm/params"
 )
 
+// fee config presets offered by the create wizard
+const (
+	SlowFeeConfig   = "slow"
+	MediumFeeConfig = "medium"
+	FastFeeConfig   = "fast"
+)
+
+var feeConfigPresetTargets = map[string]*big.Int{
+	SlowFeeConfig:   slowTarget,
+	MediumFeeConfig: mediumTarget,
+	FastFeeConfig:   fastTarget,
+}
+
+// GetFeeConfigPresetNames returns the names of the fee config presets, from slow to fast
+func GetFeeConfigPresetNames() []string {
+	return []string{SlowFeeConfig, MediumFeeConfig, FastFeeConfig}
+}
+
+// GetFeeConfigPreset returns the fee config of the [preset] offered by the create wizard
+func GetFeeConfigPreset(preset string) (commontype.FeeConfig, error) {
+	targetGas, ok := feeConfigPresetTargets[preset]
+	if !ok {
+		return commontype.FeeConfig{}, fmt.Errorf("invalid fee config preset %q, expected one of %s",
+			preset, strings.Join(GetFeeConfigPresetNames(), ", "))
+	}
+	feeConfig := StarterFeeConfig
+	feeConfig.TargetGas = targetGas
+	return feeConfig, nil
+}
+
 func GetFeeConfig(config params.ChainConfig, app *application.Avalanche) (
 	params.ChainConfig,
 	statemachine.StateDirection,
@@ -46,14 +80,14 @@ func GetFeeConfig(config params.ChainConfig, app *application.Avalanche) (
 
 	switch feeDefault {
 	case useFast:
-		config.FeeConfig.TargetGas = fastTarget
-		return config, statemachine.Forward, nil
+		config.FeeConfig, err = GetFeeConfigPreset(FastFeeConfig)
+		return config, statemachine.Forward, err
 	case useMedium:
-		config.FeeConfig.TargetGas = mediumTarget
-		return config, statemachine.Forward, nil
+		config.FeeConfig, err = GetFeeConfigPreset(MediumFeeConfig)
+		return config, statemachine.Forward, err
 	case useSlow:
-		config.FeeConfig.TargetGas = slowTarget
-		return config, statemachine.Forward, nil
+		config.FeeConfig, err = GetFeeConfigPreset(SlowFeeConfig)
+		return config, statemachine.Forward, err
 	case goBackMsg:
 		return config, statemachine.Backward, nil
 	default:
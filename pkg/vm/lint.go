@@ -0,0 +1,221 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type LintSeverity string
+
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+	LintInfo    LintSeverity = "info"
+)
+
+// rules of the genesis linter
+const (
+	MinBaseFeeRule       = "min-base-fee"
+	GasTargetRule        = "gas-target"
+	TxAllowListFundsRule = "tx-allowlist-funds"
+	NativeMinterRule     = "native-minter-admin"
+	ChainIDRule          = "chain-id"
+	EwoqAirdropRule      = "ewoq-airdrop"
+)
+
+// LintIssue is a questionable setting found in a genesis
+type LintIssue struct {
+	Severity LintSeverity `json:"severity"`
+	Rule     string       `json:"rule"`
+	Message  string       `json:"message"`
+}
+
+// minRecommendedBaseFee is the base fee under which filling blocks is cheap
+// enough for a chain to be spammed
+var minRecommendedBaseFee = big.NewInt(params.GWei)
+
+// wellKnownChainIDs are the chain IDs of public EVM chains, which wallets
+// would confuse a subnet with
+var wellKnownChainIDs = map[uint64]string{
+	1:        "Ethereum Mainnet",
+	5:        "Goerli",
+	10:       "Optimism",
+	56:       "BNB Smart Chain",
+	100:      "Gnosis",
+	137:      "Polygon",
+	250:      "Fantom",
+	1337:     "local development chains",
+	8453:     "Base",
+	31337:    "local development chains",
+	42161:    "Arbitrum One",
+	43113:    "Avalanche Fuji C-Chain",
+	43114:    "Avalanche C-Chain",
+	11155111: "Sepolia",
+}
+
+// LintEvmGenesis looks for settings of [genesis] that are valid but likely
+// mistakes. [otherChainIDs] are the chain IDs of the other local subnets, by
+// subnet name. [mainnet] tells if the genesis is the one used on Mainnet
+func LintEvmGenesis(genesis core.Genesis, otherChainIDs map[string]*big.Int, mainnet bool) []LintIssue {
+	issues := []LintIssue{}
+	if genesis.Config == nil {
+		return []LintIssue{{Severity: LintError, Rule: ChainIDRule, Message: "the genesis has no chain config"}}
+	}
+	issues = append(issues, lintFees(genesis.Config)...)
+	issues = append(issues, lintPrecompiles(genesis)...)
+	issues = append(issues, lintChainID(genesis.Config.ChainID, otherChainIDs)...)
+	if _, ok := genesis.Alloc[PrefundedEwoqAddress]; ok {
+		if mainnet {
+			issues = append(issues, LintIssue{
+				Severity: LintError,
+				Rule:     EwoqAirdropRule,
+				Message:  fmt.Sprintf("the Mainnet genesis airdrops to the default address %s, whose private key is public", PrefundedEwoqAddress.Hex()),
+			})
+		} else {
+			issues = append(issues, LintIssue{
+				Severity: LintInfo,
+				Rule:     EwoqAirdropRule,
+				Message:  fmt.Sprintf("the genesis airdrops to the default address %s, which is only suitable for test deployments", PrefundedEwoqAddress.Hex()),
+			})
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return severityRank(issues[i].Severity) < severityRank(issues[j].Severity)
+	})
+	return issues
+}
+
+func severityRank(severity LintSeverity) int {
+	switch severity {
+	case LintError:
+		return 0
+	case LintWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// HasLintErrors tells if any of [issues] is an error
+func HasLintErrors(issues []LintIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == LintError {
+			return true
+		}
+	}
+	return false
+}
+
+func lintFees(conf *params.ChainConfig) []LintIssue {
+	issues := []LintIssue{}
+	feeConfig := conf.FeeConfig
+	if feeConfig.MinBaseFee != nil {
+		switch {
+		case feeConfig.MinBaseFee.Sign() == 0:
+			issues = append(issues, LintIssue{
+				Severity: LintError,
+				Rule:     MinBaseFeeRule,
+				Message:  "the minimum base fee is 0, transactions can be free when the chain is not busy",
+			})
+		case feeConfig.MinBaseFee.Cmp(minRecommendedBaseFee) < 0:
+			issues = append(issues, LintIssue{
+				Severity: LintWarning,
+				Rule:     MinBaseFeeRule,
+				Message: fmt.Sprintf("the minimum base fee of %s wei is below %s wei, filling blocks is cheap enough to spam the chain",
+					feeConfig.MinBaseFee, minRecommendedBaseFee),
+			})
+		}
+	}
+	if feeConfig.GasLimit != nil && feeConfig.TargetGas != nil {
+		// the target gas is the gas consumed during a rollup window, which
+		// holds at most a block per second
+		maxWindowGas := new(big.Int).Mul(feeConfig.GasLimit, new(big.Int).SetUint64(params.RollupWindow))
+		switch {
+		case feeConfig.TargetGas.Cmp(maxWindowGas) > 0:
+			issues = append(issues, LintIssue{
+				Severity: LintWarning,
+				Rule:     GasTargetRule,
+				Message: fmt.Sprintf("the target gas of %s is above the %s gas of a full block every second, the base fee will never increase",
+					feeConfig.TargetGas, maxWindowGas),
+			})
+		case feeConfig.TargetGas.Cmp(feeConfig.GasLimit) < 0:
+			issues = append(issues, LintIssue{
+				Severity: LintWarning,
+				Rule:     GasTargetRule,
+				Message: fmt.Sprintf("the target gas of %s is below the gas limit of %s, a single full block exceeds the target of a %ds window",
+					feeConfig.TargetGas, feeConfig.GasLimit, params.RollupWindow),
+			})
+		}
+	}
+	return issues
+}
+
+func lintPrecompiles(genesis core.Genesis) []LintIssue {
+	issues := []LintIssue{}
+	if cfg, ok := genesis.Config.GenesisPrecompiles[txallowlist.ConfigKey].(*txallowlist.Config); ok {
+		addresses := append(append([]common.Address{}, cfg.AdminAddresses...), cfg.EnabledAddresses...)
+		funded := false
+		for _, address := range addresses {
+			if account, ok := genesis.Alloc[address]; ok && account.Balance != nil && account.Balance.Sign() > 0 {
+				funded = true
+				break
+			}
+		}
+		if !funded {
+			issues = append(issues, LintIssue{
+				Severity: LintError,
+				Rule:     TxAllowListFundsRule,
+				Message:  "the transaction allow list is enabled, but none of its admin or enabled addresses is funded, so no transaction can be paid for",
+			})
+		}
+	}
+	if cfg, ok := genesis.Config.GenesisPrecompiles[nativeminter.ConfigKey].(*nativeminter.Config); ok && len(cfg.AdminAddresses) == 0 {
+		issues = append(issues, LintIssue{
+			Severity: LintWarning,
+			Rule:     NativeMinterRule,
+			Message:  "the native minter has no admin, the addresses allowed to mint can never be changed",
+		})
+	}
+	return issues
+}
+
+func lintChainID(chainID *big.Int, otherChainIDs map[string]*big.Int) []LintIssue {
+	issues := []LintIssue{}
+	if chainID == nil {
+		return issues
+	}
+	if chainID.IsUint64() {
+		if name, ok := wellKnownChainIDs[chainID.Uint64()]; ok {
+			issues = append(issues, LintIssue{
+				Severity: LintError,
+				Rule:     ChainIDRule,
+				Message:  fmt.Sprintf("the chain ID %s is already used by %s", chainID, name),
+			})
+		}
+	}
+	subnetNames := make([]string, 0, len(otherChainIDs))
+	for subnetName := range otherChainIDs {
+		subnetNames = append(subnetNames, subnetName)
+	}
+	sort.Strings(subnetNames)
+	for _, subnetName := range subnetNames {
+		if otherChainID := otherChainIDs[subnetName]; otherChainID != nil && otherChainID.Cmp(chainID) == 0 {
+			issues = append(issues, LintIssue{
+				Severity: LintWarning,
+				Rule:     ChainIDRule,
+				Message:  fmt.Sprintf("the chain ID %s is also used by subnet %s", chainID, subnetName),
+			})
+		}
+	}
+	return issues
+}
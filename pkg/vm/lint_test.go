@@ -0,0 +1,72 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func getLintTestGenesis() core.Genesis {
+	conf := *params.SubnetEVMDefaultChainConfig
+	conf.ChainID = big.NewInt(99999)
+	conf.FeeConfig = StarterFeeConfig
+	conf.GenesisPrecompiles = map[string]precompileconfig.Config{}
+	return core.Genesis{
+		Config: &conf,
+		Alloc: core.GenesisAlloc{
+			testAirdropAddress: {Balance: big.NewInt(1)},
+		},
+	}
+}
+
+func lintRules(issues []LintIssue) []string {
+	rules := []string{}
+	for _, issue := range issues {
+		rules = append(rules, string(issue.Severity)+":"+issue.Rule)
+	}
+	return rules
+}
+
+func TestLintEvmGenesis(t *testing.T) {
+	require := setupTest(t)
+
+	genesis := getLintTestGenesis()
+	require.Empty(LintEvmGenesis(genesis, nil, true))
+
+	genesis = getLintTestGenesis()
+	genesis.Config.FeeConfig.MinBaseFee = big.NewInt(1000)
+	genesis.Config.FeeConfig.TargetGas = big.NewInt(1_000_000)
+	require.Equal([]string{"warning:min-base-fee", "warning:gas-target"}, lintRules(LintEvmGenesis(genesis, nil, true)))
+
+	genesis = getLintTestGenesis()
+	genesis.Config.FeeConfig.MinBaseFee = big.NewInt(0)
+	genesis.Config.FeeConfig.TargetGas = big.NewInt(100_000_000)
+	require.Equal([]string{"error:min-base-fee", "warning:gas-target"}, lintRules(LintEvmGenesis(genesis, nil, true)))
+
+	genesis = getLintTestGenesis()
+	genesis.Config.GenesisPrecompiles[txallowlist.ConfigKey] = txallowlist.NewConfig(nil, []common.Address{{}}, nil)
+	genesis.Config.GenesisPrecompiles[nativeminter.ConfigKey] = nativeminter.NewConfig(nil, nil, []common.Address{{}}, nil)
+	require.Equal([]string{"error:tx-allowlist-funds", "warning:native-minter-admin"}, lintRules(LintEvmGenesis(genesis, nil, true)))
+	genesis.Config.GenesisPrecompiles[txallowlist.ConfigKey] = txallowlist.NewConfig(nil, nil, []common.Address{testAirdropAddress})
+	require.Equal([]string{"warning:native-minter-admin"}, lintRules(LintEvmGenesis(genesis, nil, true)))
+
+	genesis = getLintTestGenesis()
+	genesis.Config.ChainID = big.NewInt(43114)
+	otherChainIDs := map[string]*big.Int{"other": big.NewInt(43114), "another": big.NewInt(1)}
+	require.Equal([]string{"error:chain-id", "warning:chain-id"}, lintRules(LintEvmGenesis(genesis, otherChainIDs, true)))
+
+	genesis = getLintTestGenesis()
+	genesis.Alloc[PrefundedEwoqAddress] = core.GenesisAccount{Balance: big.NewInt(1)}
+	require.Equal([]string{"error:ewoq-airdrop"}, lintRules(LintEvmGenesis(genesis, nil, true)))
+	require.Equal([]string{"info:ewoq-airdrop"}, lintRules(LintEvmGenesis(genesis, nil, false)))
+	require.False(HasLintErrors(LintEvmGenesis(genesis, nil, false)))
+}